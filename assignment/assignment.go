@@ -0,0 +1,332 @@
+package assignment
+
+import (
+	"errors"
+	"fmt"
+	"gomo/lpt"
+	"gomo/matrix"
+	"math"
+)
+
+// Forbidden returns the value (+Inf) marking a cell that can't be used in the assignment
+func Forbidden() float64 {
+	return math.Inf(1)
+}
+
+func isForbidden(value float64) bool {
+	return math.IsInf(value, 1)
+}
+
+var (
+	// ErrInvalidCost is returned when a cost is NaN or -Inf
+	ErrInvalidCost = errors.New("assignment: cost is NaN or -Inf")
+	// ErrInfeasible is returned when every complete assignment uses a forbidden cell
+	ErrInfeasible = errors.New("assignment: no assignment avoids forbidden cells")
+)
+
+// Step is one table of the Hungarian method
+type Step struct {
+	Description    string
+	Table          matrix.Matrix
+	CoveredRows    []bool
+	CoveredColumns []bool
+}
+
+// Solution contains data about an assignment problem solution
+type Solution struct {
+	// Assignment maps every row (worker) to its column (task), -1 means the row got nothing
+	Assignment []int
+	Cost       float64
+	Steps      []Step
+}
+
+// Solve solves the assignment problem using the Hungarian (Kuhn-Munkres) method.
+// Rectangular matrices are padded with zero rows or columns, cells equal to Forbidden() are never assigned
+func Solve(costs matrix.Matrix, bound lpt.Bound) (Solution, error) {
+	if err := costs.Validate(); err != nil {
+		return Solution{}, err
+	}
+
+	for _, row := range costs {
+		for _, value := range row {
+			if math.IsNaN(value) || math.IsInf(value, -1) {
+				return Solution{}, ErrInvalidCost
+			}
+		}
+	}
+
+	w, h := costs.Size()
+	table, forbidden := prepareTable(costs, bound)
+	n := len(table)
+
+	steps := []Step{{"Prepared square table", table.Clone(), nil, nil}}
+
+	for y, row := range table {
+		min := minValue(row)
+		for x := range row {
+			table[y][x] -= min
+		}
+	}
+	steps = append(steps, Step{"Subtracted row minimums", table.Clone(), nil, nil})
+
+	for x := 0; x < n; x++ {
+		min := minValue(table.GetColumn(x))
+		for y := range table {
+			table[y][x] -= min
+		}
+	}
+	steps = append(steps, Step{"Subtracted column minimums", table.Clone(), nil, nil})
+
+	var match []int
+	for {
+		var size int
+		match, size = maxZeroMatching(table)
+		if size == n {
+			break
+		}
+
+		coveredRows, coveredColumns := minCover(table, match)
+		steps = append(steps, Step{
+			fmt.Sprintf("Covered zeros with %d lines", countTrue(coveredRows)+countTrue(coveredColumns)),
+			table.Clone(),
+			coveredRows,
+			coveredColumns,
+		})
+
+		min := math.MaxFloat64
+		for y, row := range table {
+			for x, value := range row {
+				if !coveredRows[y] && !coveredColumns[x] {
+					min = math.Min(min, value)
+				}
+			}
+		}
+
+		for y, row := range table {
+			for x := range row {
+				if !coveredRows[y] && !coveredColumns[x] {
+					table[y][x] -= min
+				} else if coveredRows[y] && coveredColumns[x] {
+					table[y][x] += min
+				}
+			}
+		}
+		steps = append(steps, Step{
+			fmt.Sprintf("Subtracted %s from uncovered cells and added it to doubly covered ones", matrix.HumaniazeValue(min)),
+			table.Clone(),
+			nil,
+			nil,
+		})
+	}
+
+	assignment := make([]int, h)
+	cost := 0.0
+	for y := 0; y < h; y++ {
+		x := match[y]
+		if x >= w {
+			assignment[y] = -1
+			continue
+		}
+
+		if forbidden[y][x] {
+			return Solution{}, ErrInfeasible
+		}
+
+		assignment[y] = x
+		cost += costs[y][x]
+	}
+
+	return Solution{
+		Assignment: assignment,
+		Cost:       cost,
+		Steps:      steps,
+	}, nil
+}
+
+// prepareTable makes a square minimisation table: pads it with zeros,
+// inverts values for maximisation and replaces forbidden cells with a big value
+func prepareTable(costs matrix.Matrix, bound lpt.Bound) (matrix.Matrix, [][]bool) {
+	w, h := costs.Size()
+	n := w
+	if h > n {
+		n = h
+	}
+
+	maxAbs := 0.0
+	maxValue := -math.MaxFloat64
+	for _, row := range costs {
+		for _, value := range row {
+			if isForbidden(value) {
+				continue
+			}
+
+			maxAbs = math.Max(maxAbs, math.Abs(value))
+			maxValue = math.Max(maxValue, value)
+		}
+	}
+
+	// any assignment avoiding the big value is cheaper than one using it
+	bigValue := (maxAbs*2+1)*float64(n) + 1
+
+	table := matrix.ShellM(n, n)
+	forbidden := make([][]bool, n)
+	for y := range table {
+		forbidden[y] = make([]bool, n)
+		if y >= h {
+			continue
+		}
+
+		for x, value := range costs[y] {
+			switch {
+			case isForbidden(value):
+				table[y][x] = bigValue
+				forbidden[y][x] = true
+			case bound == lpt.BoundMax:
+				table[y][x] = maxValue - value
+			default:
+				table[y][x] = value
+			}
+		}
+	}
+
+	return table, forbidden
+}
+
+func minValue(v matrix.Vector) float64 {
+	min := math.MaxFloat64
+	for _, value := range v {
+		min = math.Min(min, value)
+	}
+
+	return min
+}
+
+// maxZeroMatching finds maximal matching of rows and columns on zero cells (Kuhn's algorithm).
+// Returns column matched to each row (-1 if none) and matching size
+func maxZeroMatching(table matrix.Matrix) ([]int, int) {
+	n := len(table)
+	rowOf := make([]int, n)
+	columnOf := make([]int, n)
+	for i := range rowOf {
+		rowOf[i] = -1
+		columnOf[i] = -1
+	}
+
+	var augment func(y int, visited []bool) bool
+	augment = func(y int, visited []bool) bool {
+		for x, value := range table[y] {
+			if math.Abs(value) > matrix.Epsilon || visited[x] {
+				continue
+			}
+
+			visited[x] = true
+			if rowOf[x] == -1 || augment(rowOf[x], visited) {
+				rowOf[x] = y
+				columnOf[y] = x
+				return true
+			}
+		}
+
+		return false
+	}
+
+	size := 0
+	for y := 0; y < n; y++ {
+		if augment(y, make([]bool, n)) {
+			size++
+		}
+	}
+
+	return columnOf, size
+}
+
+// minCover finds minimal set of rows and columns covering every zero (König's theorem)
+func minCover(table matrix.Matrix, match []int) ([]bool, []bool) {
+	n := len(table)
+	rowOf := make([]int, n)
+	for i := range rowOf {
+		rowOf[i] = -1
+	}
+	for y, x := range match {
+		if x != -1 {
+			rowOf[x] = y
+		}
+	}
+
+	visitedRows := make([]bool, n)
+	visitedColumns := make([]bool, n)
+
+	queue := []int{}
+	for y, x := range match {
+		if x == -1 {
+			visitedRows[y] = true
+			queue = append(queue, y)
+		}
+	}
+
+	for len(queue) > 0 {
+		y := queue[0]
+		queue = queue[1:]
+
+		for x, value := range table[y] {
+			if math.Abs(value) > matrix.Epsilon || visitedColumns[x] {
+				continue
+			}
+
+			visitedColumns[x] = true
+			if next := rowOf[x]; next != -1 && !visitedRows[next] {
+				visitedRows[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	coveredRows := make([]bool, n)
+	for y, visited := range visitedRows {
+		coveredRows[y] = !visited
+	}
+
+	return coveredRows, visitedColumns
+}
+
+func countTrue(values []bool) int {
+	count := 0
+	for _, value := range values {
+		if value {
+			count++
+		}
+	}
+
+	return count
+}
+
+// String stringifies the step with matrix.DefaultFormatter marking covered rows and columns with "*"
+func (s Step) String() string {
+	cells := make([][]string, len(s.Table))
+	for y, row := range s.Table {
+		cells[y] = make([]string, len(row))
+		for x, value := range row {
+			mark := " "
+			if (s.CoveredRows != nil && s.CoveredRows[y]) || (s.CoveredColumns != nil && s.CoveredColumns[x]) {
+				mark = "*"
+			}
+
+			cells[y][x] = matrix.DefaultFormatter.FormatValue(value) + mark
+		}
+	}
+
+	return s.Description + ":\n" + matrix.DefaultFormatter.FormatTable(cells)
+}
+
+func (s Solution) String() string {
+	str := ""
+	for y, x := range s.Assignment {
+		if x == -1 {
+			str += fmt.Sprintf("%d -> none\n", y+1)
+		} else {
+			str += fmt.Sprintf("%d -> %d\n", y+1, x+1)
+		}
+	}
+
+	return str + fmt.Sprintf("cost:\t%f", s.Cost)
+}
@@ -0,0 +1,104 @@
+package assignment
+
+import (
+	"gomo/lpt"
+	"gomo/matrix"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		costs          matrix.Matrix
+		bound          lpt.Bound
+		wantAssignment []int
+		wantCost       float64
+	}{
+		{"min", matrix.Matrix{
+			{9, 2, 7, 8},
+			{6, 4, 3, 7},
+			{5, 8, 1, 8},
+			{7, 6, 9, 4},
+		}, lpt.BoundMin, []int{1, 0, 2, 3}, 13},
+		{"max", matrix.Matrix{
+			{9, 2, 7, 8},
+			{6, 4, 3, 7},
+			{5, 8, 1, 8},
+			{7, 6, 9, 4},
+		}, lpt.BoundMax, []int{0, 3, 1, 2}, 33},
+		{"more rows", matrix.Matrix{
+			{4, 1},
+			{2, 3},
+			{1, 5},
+		}, lpt.BoundMin, []int{1, -1, 0}, 2},
+		{"more columns", matrix.Matrix{
+			{4, 1, 4},
+			{2, 0, 5},
+		}, lpt.BoundMin, []int{1, 0}, 3},
+		{"forbidden", matrix.Matrix{
+			{1, Forbidden(), 3},
+			{Forbidden(), 1, 4},
+			{2, 2, Forbidden()},
+		}, lpt.BoundMin, []int{2, 1, 0}, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Solve(tt.costs, tt.bound)
+			if err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Assignment, tt.wantAssignment) {
+				t.Errorf("Solve() assignment = %v, want %v", got.Assignment, tt.wantAssignment)
+			}
+			if got.Cost != tt.wantCost {
+				t.Errorf("Solve() cost = %v, want %v", got.Cost, tt.wantCost)
+			}
+		})
+	}
+}
+
+func TestSolveInfeasible(t *testing.T) {
+	costs := matrix.Matrix{
+		{Forbidden(), Forbidden()},
+		{1, 2},
+	}
+
+	if _, err := Solve(costs, lpt.BoundMin); err != ErrInfeasible {
+		t.Errorf("Solve() error = %v, want %v", err, ErrInfeasible)
+	}
+}
+
+func TestSolveInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		costs   matrix.Matrix
+		wantErr error
+	}{
+		{"empty", matrix.Matrix{}, matrix.ErrEmpty},
+		{"ragged", matrix.Matrix{{1, 2}, {3}}, matrix.ErrDimensionMismatch},
+		{"NaN", matrix.Matrix{{1, math.NaN()}, {3, 4}}, ErrInvalidCost},
+		{"-Inf", matrix.Matrix{{1, 2}, {math.Inf(-1), 4}}, ErrInvalidCost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Solve(tt.costs, lpt.BoundMin); err != tt.wantErr {
+				t.Errorf("Solve() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStepString(t *testing.T) {
+	step := Step{
+		Description: "Covered",
+		Table:       matrix.Matrix{{1, 0}, {0, 2.5}},
+		CoveredRows: []bool{true, false},
+	}
+
+	want := "Covered:\n1.000* 0.000* \n0.000  2.500  \n"
+	if got := step.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,447 @@
+package cooperative
+
+import (
+	"errors"
+	"fmt"
+	"gomo/lpt"
+	"gomo/matrix"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrPlayersCount is returned when the game has no players or too many of them
+	ErrPlayersCount = errors.New("cooperative: players count must be in range [1, 20]")
+	// ErrValuesCount is returned when the characteristic function isn't defined on every coalition
+	ErrValuesCount = errors.New("cooperative: characteristic function must have 2^n values")
+	// ErrEmptyCoalitionWorth is returned when the empty coalition is worth something
+	ErrEmptyCoalitionWorth = errors.New("cooperative: empty coalition must be worth 0")
+	// ErrNoImputations is returned when the players can't get their individual worths together
+	ErrNoImputations = errors.New("cooperative: grand coalition is worth less than the players alone")
+	// ErrNoSolution is returned when the simplex method result doesn't satisfy the task
+	ErrNoSolution = errors.New("cooperative: simplex method failed to find a solution")
+)
+
+// Coalition is a set of players, player i is in the coalition when bit i is set
+type Coalition uint
+
+// Contains checks if the player is in the coalition
+func (c Coalition) Contains(player int) bool {
+	return c&(1<<uint(player)) != 0
+}
+
+// Size returns count of the players in the coalition
+func (c Coalition) Size() int {
+	size := 0
+	for ; c != 0; c &= c - 1 {
+		size++
+	}
+
+	return size
+}
+
+// With returns the coalition with the player added
+func (c Coalition) With(player int) Coalition {
+	return c | 1<<uint(player)
+}
+
+func (c Coalition) String() string {
+	players := []string{}
+	for i := 0; c>>uint(i) != 0; i++ {
+		if c.Contains(i) {
+			players = append(players, fmt.Sprint(i+1))
+		}
+	}
+
+	return "{" + strings.Join(players, ", ") + "}"
+}
+
+// Game is a cooperative game given by the characteristic function
+type Game struct {
+	Players int
+	// Values[c] is the worth of coalition c
+	Values matrix.Vector
+}
+
+// CoreResult contains data about the core of a game
+type CoreResult struct {
+	Empty bool
+	// Allocation is the cheapest allocation satisfying every coalition, it's in the core when the core isn't empty
+	Allocation matrix.Vector
+	// Total is the sum of Allocation, the core is empty when it's bigger than the worth of the grand coalition
+	Total float64
+	Task  lpt.LPT
+}
+
+// NewGame makes a game from worths of every coalition
+func NewGame(players int, values matrix.Vector) (Game, error) {
+	if players < 1 || players > 20 {
+		return Game{}, ErrPlayersCount
+	}
+
+	if len(values) != 1<<uint(players) {
+		return Game{}, ErrValuesCount
+	}
+
+	if values[0] != 0 {
+		return Game{}, ErrEmptyCoalitionWorth
+	}
+
+	return Game{Players: players, Values: values}, nil
+}
+
+// Grand returns the coalition of every player
+func (g Game) Grand() Coalition {
+	return Coalition(1<<uint(g.Players) - 1)
+}
+
+// Worth returns the worth of the coalition
+func (g Game) Worth(c Coalition) float64 {
+	return g.Values[c]
+}
+
+// factorial returns n! as float
+func factorial(n int) float64 {
+	f := 1.0
+	for i := 2; i <= n; i++ {
+		f *= float64(i)
+	}
+
+	return f
+}
+
+// Shapley calculates the Shapley value: average marginal contribution over every order of joining
+func (g Game) Shapley() matrix.Vector {
+	n := g.Players
+	shapley := matrix.ShellV(n)
+
+	for player := 0; player < n; player++ {
+		for c := Coalition(0); c <= g.Grand(); c++ {
+			if c.Contains(player) {
+				continue
+			}
+
+			s := c.Size()
+			weight := factorial(s) * factorial(n-s-1) / factorial(n)
+			shapley[player] += weight * (g.Worth(c.With(player)) - g.Worth(c))
+		}
+	}
+
+	return shapley
+}
+
+// Banzhaf calculates the Banzhaf value: average marginal contribution over every coalition without the player
+func (g Game) Banzhaf() matrix.Vector {
+	banzhaf := matrix.ShellV(g.Players)
+
+	for player := range banzhaf {
+		for c := Coalition(0); c <= g.Grand(); c++ {
+			if !c.Contains(player) {
+				banzhaf[player] += g.Worth(c.With(player)) - g.Worth(c)
+			}
+		}
+
+		banzhaf[player] /= float64(int(1) << uint(g.Players-1))
+	}
+
+	return banzhaf
+}
+
+// NormalizedBanzhaf calculates the Banzhaf index: the Banzhaf value scaled to sum to 1
+func (g Game) NormalizedBanzhaf() matrix.Vector {
+	banzhaf := g.Banzhaf()
+	sum := banzhaf.Sum()
+	if math.Abs(sum) < matrix.Epsilon {
+		return banzhaf
+	}
+
+	return banzhaf.MultiplyWithNumber(1 / sum)
+}
+
+// coalitionRow makes the limitation row x(c) ? b
+func (g Game) coalitionRow(c Coalition, b float64, extra int) matrix.Vector {
+	row := matrix.ShellV(g.Players + extra + 1)
+	for i := 0; i < g.Players; i++ {
+		if c.Contains(i) {
+			row[i] = 1
+		}
+	}
+	row[len(row)-1] = b
+
+	return row
+}
+
+// IsInCore checks if the allocation gives the grand coalition worth and every coalition at least its worth
+func (g Game) IsInCore(allocation matrix.Vector) bool {
+	if len(allocation) != g.Players || math.Abs(allocation.Sum()-g.Worth(g.Grand())) > lpt.SolutionTolerance {
+		return false
+	}
+
+	for c := Coalition(1); c < g.Grand(); c++ {
+		if g.coalitionRow(c, 0, 0)[:g.Players].MultiplyElementByElement(allocation).Sum() < g.Worth(c)-lpt.SolutionTolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// individualWorths returns worths of the players alone
+func (g Game) individualWorths() matrix.Vector {
+	worths := matrix.ShellV(g.Players)
+	for i := range worths {
+		worths[i] = g.Worth(Coalition(0).With(i))
+	}
+
+	return worths
+}
+
+// shiftedWorth is the worth of the coalition above the individual worths of its players,
+// the allocation is shifted the same way so every its value is non-negative in the core
+func (g Game) shiftedWorth(c Coalition, worths matrix.Vector) float64 {
+	w := g.Worth(c)
+	for i, worth := range worths {
+		if c.Contains(i) {
+			w -= worth
+		}
+	}
+
+	return w
+}
+
+// CoreLPT builds the task of finding the cheapest allocation satisfying every coalition:
+// Z = y1 + ... + yn -> min, y(S) >= v(S) - v({i in S}), y >= 0, where x = y + individual worths
+func (g Game) CoreLPT() lpt.LPT {
+	worths := g.individualWorths()
+
+	m := matrix.Matrix{}
+	operators := []lpt.Operator{}
+	for c := Coalition(1); c <= g.Grand(); c++ {
+		if c.Size() == 1 {
+			continue
+		}
+
+		m = append(m, g.coalitionRow(c, g.shiftedWorth(c, worths), 0))
+		operators = append(operators, lpt.OperatorGreaterOrEqual)
+	}
+
+	if len(m) == 0 {
+		// the only player
+		m = append(m, g.coalitionRow(g.Grand(), 0, 0))
+		operators = append(operators, lpt.OperatorGreaterOrEqual)
+	}
+
+	return lpt.LPT{}.
+		SetMatrix(m, operators).
+		SetSignConditionToEvery(lpt.OperatorGreaterOrEqual).
+		SetTargetCoeffs(matrix.ShellVWithValue(g.Players, 1), lpt.BoundMin)
+}
+
+// Core checks if the core is empty solving CoreLPT with LPT.Solve
+func (g Game) Core() (CoreResult, error) {
+	task := g.CoreLPT()
+
+	y, err := solve(task)
+	if err != nil {
+		return CoreResult{}, err
+	}
+
+	allocation := y.Clone()
+	for i, worth := range g.individualWorths() {
+		allocation[i] += worth
+	}
+
+	total := allocation.Sum()
+
+	return CoreResult{
+		Empty:      total > g.Worth(g.Grand())+lpt.SolutionTolerance,
+		Allocation: allocation,
+		Total:      total,
+		Task:       task,
+	}, nil
+}
+
+// solve solves the task with LPT.Solve and checks the result within lpt.SolutionTolerance
+func solve(task lpt.LPT) (matrix.Vector, error) {
+	variables := len(task.TargetCoeffs())
+
+	values, _, err := task.Solve()
+	if err != nil {
+		return nil, err
+	}
+
+	operators := task.Operators()
+	for y, row := range task.LimitationsAsMatrix() {
+		left := row[:variables].MultiplyElementByElement(values).Sum()
+		b := row[variables]
+
+		isSatisfied := true
+		switch operators[y] {
+		case lpt.OperatorGreater, lpt.OperatorGreaterOrEqual:
+			isSatisfied = left >= b-lpt.SolutionTolerance
+		case lpt.OperatorLess, lpt.OperatorLessOrEqual:
+			isSatisfied = left <= b+lpt.SolutionTolerance
+		case lpt.OperatorEqual:
+			isSatisfied = math.Abs(left-b) <= lpt.SolutionTolerance
+		}
+
+		if !isSatisfied {
+			return nil, ErrNoSolution
+		}
+	}
+
+	for _, value := range values {
+		if value < -lpt.SolutionTolerance {
+			return nil, ErrNoSolution
+		}
+	}
+
+	return values, nil
+}
+
+// nucleolusTask builds the task of minimizing the maximal excess e = e+ - e- of the free coalitions
+// keeping the excesses of the fixed ones. Variables are y (allocation above individual worths), e+ and e-
+func (g Game) nucleolusTask(worths matrix.Vector, fixed map[Coalition]float64, free []Coalition) lpt.LPT {
+	n := g.Players
+
+	m := matrix.Matrix{g.coalitionRow(g.Grand(), g.shiftedWorth(g.Grand(), worths), 2)}
+	operators := []lpt.Operator{lpt.OperatorEqual}
+
+	for c := Coalition(1); c < g.Grand(); c++ {
+		if excess, ok := fixed[c]; ok {
+			m = append(m, g.coalitionRow(c, g.shiftedWorth(c, worths)-excess, 2))
+			operators = append(operators, lpt.OperatorEqual)
+		}
+	}
+
+	// y(S) + e >= v'(S) means the excess of S is at most e
+	for _, c := range free {
+		row := g.coalitionRow(c, g.shiftedWorth(c, worths), 2)
+		row[n] = 1
+		row[n+1] = -1
+
+		m = append(m, row)
+		operators = append(operators, lpt.OperatorGreaterOrEqual)
+	}
+
+	coeffs := matrix.ShellV(n + 2)
+	coeffs[n] = 1
+	coeffs[n+1] = -1
+
+	return lpt.LPT{}.
+		SetMatrix(m, operators).
+		SetSignConditionToEvery(lpt.OperatorGreaterOrEqual).
+		SetTargetCoeffs(coeffs, lpt.BoundMin)
+}
+
+// Nucleolus finds the imputation lexicographically minimizing the sorted excesses of the coalitions.
+// Every round minimizes the maximal excess of the free coalitions and fixes the ones that can't do better
+func (g Game) Nucleolus() (matrix.Vector, error) {
+	n := g.Players
+	worths := g.individualWorths()
+	if g.shiftedWorth(g.Grand(), worths) < -matrix.Epsilon {
+		return nil, ErrNoImputations
+	}
+
+	fixed := map[Coalition]float64{}
+	free := []Coalition{}
+	for c := Coalition(1); c < g.Grand(); c++ {
+		free = append(free, c)
+	}
+
+	y := matrix.ShellV(n)
+	y[0] = g.shiftedWorth(g.Grand(), worths)
+
+	for len(free) != 0 {
+		values, err := solve(g.nucleolusTask(worths, fixed, free))
+		if err != nil {
+			return nil, err
+		}
+
+		y = values[:n]
+		excess := values[n] - values[n+1]
+
+		// S is fixed when no optimal allocation gives it more than y(S) = v'(S) - e
+		stillFree := []Coalition{}
+		newlyFixed := []Coalition{}
+		for _, c := range free {
+			target := g.shiftedWorth(c, worths) - excess
+			if g.coalitionRow(c, 0, 0)[:n].MultiplyElementByElement(y).Sum() > target+lpt.SolutionTolerance {
+				stillFree = append(stillFree, c)
+				continue
+			}
+
+			best, err := g.bestPayoff(worths, fixed, free, excess, c)
+			if err != nil {
+				return nil, err
+			}
+
+			if best > target+lpt.SolutionTolerance {
+				stillFree = append(stillFree, c)
+			} else {
+				newlyFixed = append(newlyFixed, c)
+			}
+		}
+
+		if len(newlyFixed) == 0 {
+			return nil, ErrNoSolution
+		}
+
+		for _, c := range newlyFixed {
+			fixed[c] = excess
+		}
+		free = stillFree
+	}
+
+	nucleolus := y.Clone()
+	for i, worth := range worths {
+		nucleolus[i] += worth
+	}
+
+	return nucleolus, nil
+}
+
+// bestPayoff finds the maximal y(c) keeping the excesses of the fixed coalitions
+// and the excesses of the free ones not bigger than provided value
+func (g Game) bestPayoff(worths matrix.Vector, fixed map[Coalition]float64, free []Coalition, excess float64, c Coalition) (float64, error) {
+	n := g.Players
+
+	m := matrix.Matrix{g.coalitionRow(g.Grand(), g.shiftedWorth(g.Grand(), worths), 0)}
+	operators := []lpt.Operator{lpt.OperatorEqual}
+
+	for fixedCoalition := Coalition(1); fixedCoalition < g.Grand(); fixedCoalition++ {
+		if fixedExcess, ok := fixed[fixedCoalition]; ok {
+			m = append(m, g.coalitionRow(fixedCoalition, g.shiftedWorth(fixedCoalition, worths)-fixedExcess, 0))
+			operators = append(operators, lpt.OperatorEqual)
+		}
+	}
+
+	for _, freeCoalition := range free {
+		m = append(m, g.coalitionRow(freeCoalition, g.shiftedWorth(freeCoalition, worths)-excess, 0))
+		operators = append(operators, lpt.OperatorGreaterOrEqual)
+	}
+
+	// maximizing y(c) is minimizing -y(c)
+	coeffs := g.coalitionRow(c, 0, 0)[:n].MultiplyWithNumber(-1)
+
+	values, err := solve(lpt.LPT{}.
+		SetMatrix(m, operators).
+		SetSignConditionToEvery(lpt.OperatorGreaterOrEqual).
+		SetTargetCoeffs(coeffs, lpt.BoundMin))
+	if err != nil {
+		return 0, err
+	}
+
+	return -coeffs.MultiplyElementByElement(values).Sum(), nil
+}
+
+func (r CoreResult) String() string {
+	str := "core LPT:\n" + r.Task.String() + "\n"
+	if r.Empty {
+		str += "core is empty\n"
+	} else {
+		str += "core is not empty\n"
+	}
+
+	return str + fmt.Sprintf("allocation: %s\ntotal: %f", r.Allocation, r.Total)
+}
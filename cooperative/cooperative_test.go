@@ -0,0 +1,95 @@
+package cooperative
+
+import (
+	"gomo/matrix"
+	"math"
+	"testing"
+)
+
+func almostEqual(v1, v2 matrix.Vector) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+
+	for i := range v1 {
+		if math.Abs(v1[i]-v2[i]) > 1e-6 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestValues(t *testing.T) {
+	// the first player has a left glove, the others have right ones
+	g, err := NewGame(3, matrix.Vector{0, 0, 0, 1, 0, 1, 0, 1})
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	if got, want := g.Shapley(), (matrix.Vector{2.0 / 3, 1.0 / 6, 1.0 / 6}); !almostEqual(got, want) {
+		t.Errorf("Shapley() = %v, want %v", got, want)
+	}
+	if got, want := g.Banzhaf(), (matrix.Vector{0.75, 0.25, 0.25}); !almostEqual(got, want) {
+		t.Errorf("Banzhaf() = %v, want %v", got, want)
+	}
+	if got, want := g.NormalizedBanzhaf(), (matrix.Vector{0.6, 0.2, 0.2}); !almostEqual(got, want) {
+		t.Errorf("NormalizedBanzhaf() = %v, want %v", got, want)
+	}
+}
+
+func TestCoreAndNucleolus(t *testing.T) {
+	tests := []struct {
+		name          string
+		values        matrix.Vector
+		wantEmpty     bool
+		wantNucleolus matrix.Vector
+	}{
+		{"gloves", matrix.Vector{0, 0, 0, 1, 0, 1, 0, 1}, false, matrix.Vector{1, 0, 0}},
+		{"strong pairs", matrix.Vector{0, 0, 0, 0.8, 0, 0.8, 0.8, 1}, true, matrix.Vector{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{"individual worths", matrix.Vector{0, 1, 2, 4, 0, 3, 4, 8}, false, matrix.Vector{2.5, 3.5, 2}},
+		// a pair is worth more than the grand coalition, so the core is empty
+		{"only imputation", matrix.Vector{0, 6, 0, 8, 4, 6, 4, 10}, true, matrix.Vector{6, 0, 4}},
+		{"strong pair 1", matrix.Vector{0, 0, 7, 10, 0, 2, 5, 9}, true, matrix.Vector{2, 7, 0}},
+		{"strong pair 2", matrix.Vector{0, 1, 0, 11, 1, 3, 6, 7}, true, matrix.Vector{1.5, 4.5, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGame(3, tt.values)
+			if err != nil {
+				t.Fatalf("NewGame() error = %v", err)
+			}
+
+			core, err := g.Core()
+			if err != nil {
+				t.Fatalf("Core() error = %v", err)
+			}
+			if core.Empty != tt.wantEmpty {
+				t.Errorf("Core() empty = %v, want %v", core.Empty, tt.wantEmpty)
+			}
+			if !core.Empty && !g.IsInCore(core.Allocation) {
+				t.Errorf("Core() allocation %v is not in the core", core.Allocation)
+			}
+
+			nucleolus, err := g.Nucleolus()
+			if err != nil {
+				t.Fatalf("Nucleolus() error = %v", err)
+			}
+			if !almostEqual(nucleolus, tt.wantNucleolus) {
+				t.Errorf("Nucleolus() = %v, want %v", nucleolus, tt.wantNucleolus)
+			}
+		})
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	if _, err := NewGame(2, matrix.Vector{0, 1, 1}); err != ErrValuesCount {
+		t.Errorf("NewGame() error = %v, want %v", err, ErrValuesCount)
+	}
+	if _, err := NewGame(1, matrix.Vector{1, 1}); err != ErrEmptyCoalitionWorth {
+		t.Errorf("NewGame() error = %v, want %v", err, ErrEmptyCoalitionWorth)
+	}
+	if _, err := NewGame(0, matrix.Vector{0}); err != ErrPlayersCount {
+		t.Errorf("NewGame() error = %v, want %v", err, ErrPlayersCount)
+	}
+}
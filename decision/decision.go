@@ -0,0 +1,227 @@
+package decision
+
+import (
+	"errors"
+	"fmt"
+	"gomo/matrix"
+	"math"
+)
+
+var (
+	// ErrCoefficientOutOfRange is returned when a criterion coefficient is not in [0, 1]
+	ErrCoefficientOutOfRange = errors.New("decision: coefficient must be in [0, 1]")
+	// ErrInvalidProbabilities is returned when state probabilities don't make a distribution over the states
+	ErrInvalidProbabilities = errors.New("decision: state probabilities must be non-negative and sum to 1")
+)
+
+// Criterion is a decision criterion under uncertainty
+type Criterion int
+
+const (
+	// CriterionWald chooses the row with the best worst payoff (maximin)
+	CriterionWald Criterion = iota
+	// CriterionSavage chooses the row with the least maximal regret (minimax regret)
+	CriterionSavage Criterion = iota
+	// CriterionHurwicz weights the best and the worst payoffs of the row
+	CriterionHurwicz Criterion = iota
+	// CriterionLaplace treats every state as equally probable
+	CriterionLaplace Criterion = iota
+	// CriterionBayes chooses the row with the best expected payoff
+	CriterionBayes Criterion = iota
+	// CriterionHodgesLehmann weights the expected and the worst payoffs of the row
+	CriterionHodgesLehmann Criterion = iota
+)
+
+func (c Criterion) String() string {
+	switch c {
+	case CriterionWald:
+		return "Wald"
+	case CriterionSavage:
+		return "Savage"
+	case CriterionHurwicz:
+		return "Hurwicz"
+	case CriterionLaplace:
+		return "Laplace"
+	case CriterionBayes:
+		return "Bayes"
+	case CriterionHodgesLehmann:
+		return "Hodges-Lehmann"
+	}
+
+	return "Undefined"
+}
+
+// Result contains data about a decision made by a criterion.
+// Rows of the payoff matrix are decisions and columns are states of nature, payoffs are gains
+type Result struct {
+	Criterion Criterion
+	// Scores are the criterion values of every row
+	Scores matrix.Vector
+	// Row is the chosen row, the first one on ties
+	Row   int
+	Score float64
+	// Regrets is the regret matrix, it's set by Savage criterion only
+	Regrets matrix.Matrix
+}
+
+func rowMin(row matrix.Vector) float64 {
+	min := row[0]
+	for _, value := range row[1:] {
+		min = math.Min(min, value)
+	}
+
+	return min
+}
+
+func rowMax(row matrix.Vector) float64 {
+	max := row[0]
+	for _, value := range row[1:] {
+		max = math.Max(max, value)
+	}
+
+	return max
+}
+
+// choose scores every row and picks the row with the maximal (or minimal) score
+func choose(criterion Criterion, m matrix.Matrix, score func(row matrix.Vector) float64, max bool) Result {
+	r := Result{Criterion: criterion, Scores: matrix.ShellV(m.Height())}
+	for y, row := range m {
+		r.Scores[y] = score(row)
+
+		isBetter := (max && r.Scores[y] > r.Scores[r.Row]+matrix.Epsilon) || (!max && r.Scores[y] < r.Scores[r.Row]-matrix.Epsilon)
+		if isBetter {
+			r.Row = y
+		}
+	}
+
+	r.Score = r.Scores[r.Row]
+
+	return r
+}
+
+// Wald chooses the row with the maximal worst payoff
+func Wald(m matrix.Matrix) (Result, error) {
+	if err := m.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	return choose(CriterionWald, m, rowMin, true), nil
+}
+
+// Regrets calculates the regret matrix: how much less than the best payoff of the state every decision gives
+func Regrets(m matrix.Matrix) matrix.Matrix {
+	w, h := m.Size()
+
+	regrets := matrix.ShellM(w, h)
+	for x := 0; x < w; x++ {
+		best := rowMax(m.GetColumn(x))
+		for y := 0; y < h; y++ {
+			regrets[y][x] = best - m[y][x]
+		}
+	}
+
+	return regrets
+}
+
+// Savage chooses the row with the minimal maximal regret
+func Savage(m matrix.Matrix) (Result, error) {
+	if err := m.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	regrets := Regrets(m)
+	r := choose(CriterionSavage, regrets, rowMax, false)
+	r.Regrets = regrets
+
+	return r, nil
+}
+
+// Hurwicz chooses the row with the maximal alpha * max + (1 - alpha) * min, alpha is the optimism coefficient
+func Hurwicz(m matrix.Matrix, alpha float64) (Result, error) {
+	if err := m.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	if alpha < 0 || alpha > 1 {
+		return Result{}, ErrCoefficientOutOfRange
+	}
+
+	return choose(CriterionHurwicz, m, func(row matrix.Vector) float64 {
+		return alpha*rowMax(row) + (1-alpha)*rowMin(row)
+	}, true), nil
+}
+
+// Laplace chooses the row with the maximal average payoff
+func Laplace(m matrix.Matrix) (Result, error) {
+	if err := m.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	return choose(CriterionLaplace, m, func(row matrix.Vector) float64 {
+		return row.Sum() / float64(len(row))
+	}, true), nil
+}
+
+func validateProbabilities(m matrix.Matrix, probabilities matrix.Vector) error {
+	if len(probabilities) != m.Width() {
+		return ErrInvalidProbabilities
+	}
+
+	for _, p := range probabilities {
+		if p < 0 {
+			return ErrInvalidProbabilities
+		}
+	}
+
+	if math.Abs(probabilities.Sum()-1) > matrix.Epsilon {
+		return ErrInvalidProbabilities
+	}
+
+	return nil
+}
+
+// Bayes chooses the row with the maximal expected payoff for provided state probabilities
+func Bayes(m matrix.Matrix, probabilities matrix.Vector) (Result, error) {
+	if err := m.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	if err := validateProbabilities(m, probabilities); err != nil {
+		return Result{}, err
+	}
+
+	return choose(CriterionBayes, m, func(row matrix.Vector) float64 {
+		return row.MultiplyElementByElement(probabilities).Sum()
+	}, true), nil
+}
+
+// HodgesLehmann chooses the row with the maximal lambda * expected + (1 - lambda) * min,
+// lambda is the confidence in the state probabilities
+func HodgesLehmann(m matrix.Matrix, probabilities matrix.Vector, lambda float64) (Result, error) {
+	if err := m.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	if err := validateProbabilities(m, probabilities); err != nil {
+		return Result{}, err
+	}
+
+	if lambda < 0 || lambda > 1 {
+		return Result{}, ErrCoefficientOutOfRange
+	}
+
+	return choose(CriterionHodgesLehmann, m, func(row matrix.Vector) float64 {
+		return lambda*row.MultiplyElementByElement(probabilities).Sum() + (1-lambda)*rowMin(row)
+	}, true), nil
+}
+
+func (r Result) String() string {
+	str := r.Criterion.String() + "\n"
+	if r.Regrets != nil {
+		str += "regrets:\n" + r.Regrets.String() + "\n"
+	}
+
+	str += fmt.Sprintf("scores: %s\nrow: %d\nscore: %f", r.Scores, r.Row+1, r.Score)
+
+	return str
+}
@@ -0,0 +1,78 @@
+package decision
+
+import (
+	"gomo/matrix"
+	"math"
+	"testing"
+)
+
+func TestCriteria(t *testing.T) {
+	m := matrix.Matrix{
+		{20, 30, 15},
+		{75, 20, 35},
+		{25, 80, 25},
+		{85, 5, 45},
+	}
+	probabilities := matrix.Vector{0.5, 0.3, 0.2}
+
+	tests := []struct {
+		name      string
+		decide    func() (Result, error)
+		wantRow   int
+		wantScore float64
+	}{
+		{"Wald", func() (Result, error) { return Wald(m) }, 2, 25},
+		{"Savage", func() (Result, error) { return Savage(m) }, 1, 60},
+		{"Hurwicz", func() (Result, error) { return Hurwicz(m, 0.5) }, 2, 52.5},
+		{"Laplace", func() (Result, error) { return Laplace(m) }, 3, 45},
+		{"Bayes", func() (Result, error) { return Bayes(m, probabilities) }, 3, 53},
+		{"Hodges-Lehmann", func() (Result, error) { return HodgesLehmann(m, probabilities, 0.5) }, 1, 35.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.decide()
+			if err != nil {
+				t.Fatalf("criterion error = %v", err)
+			}
+			if got.Row != tt.wantRow || math.Abs(got.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("criterion = (%d, %v), want (%d, %v)", got.Row, got.Score, tt.wantRow, tt.wantScore)
+			}
+			if len(got.Scores) != len(m) {
+				t.Errorf("criterion scores = %v, want %d of them", got.Scores, len(m))
+			}
+		})
+	}
+}
+
+func TestRegrets(t *testing.T) {
+	got := Regrets(matrix.Matrix{{1, 4}, {3, 2}})
+	want := matrix.Matrix{{2, 0}, {0, 2}}
+
+	for y := range want {
+		for x := range want[y] {
+			if got[y][x] != want[y][x] {
+				t.Fatalf("Regrets() = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	m := matrix.Matrix{{1, 2}, {3, 4}}
+
+	if _, err := Wald(matrix.Matrix{}); err != matrix.ErrEmpty {
+		t.Errorf("Wald() error = %v, want %v", err, matrix.ErrEmpty)
+	}
+	if _, err := Savage(matrix.Matrix{{1, 2}, {3}}); err != matrix.ErrDimensionMismatch {
+		t.Errorf("Savage() error = %v, want %v", err, matrix.ErrDimensionMismatch)
+	}
+	if _, err := Hurwicz(m, 1.5); err != ErrCoefficientOutOfRange {
+		t.Errorf("Hurwicz() error = %v, want %v", err, ErrCoefficientOutOfRange)
+	}
+	if _, err := Bayes(m, matrix.Vector{0.5, 0.6}); err != ErrInvalidProbabilities {
+		t.Errorf("Bayes() error = %v, want %v", err, ErrInvalidProbabilities)
+	}
+	if _, err := HodgesLehmann(m, matrix.Vector{1}, 0.5); err != ErrInvalidProbabilities {
+		t.Errorf("HodgesLehmann() error = %v, want %v", err, ErrInvalidProbabilities)
+	}
+}
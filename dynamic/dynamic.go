@@ -0,0 +1,276 @@
+package dynamic
+
+import (
+	"errors"
+	"fmt"
+	"gomo/matrix"
+	"math"
+)
+
+var (
+	// ErrNegativeCapacity is returned when capacity or resource amount is negative
+	ErrNegativeCapacity = errors.New("dynamic: capacity must be non-negative")
+	// ErrInvalidWeight is returned when an item weight isn't positive
+	ErrInvalidWeight = errors.New("dynamic: item weight must be positive")
+	// ErrEmpty is returned when there is nothing to solve
+	ErrEmpty = errors.New("dynamic: no stages or projects")
+	// ErrDimensionMismatch is returned when stage matrices don't fit each other
+	ErrDimensionMismatch = errors.New("dynamic: stage sizes don't match")
+	// ErrNoRoute is returned when no route goes through every stage
+	ErrNoRoute = errors.New("dynamic: no route through the stages")
+)
+
+// Item is an item of a knapsack problem, Count limits how many copies can be taken
+type Item struct {
+	Name   string
+	Weight int
+	Value  float64
+	Count  int
+}
+
+// KnapsackSolution contains data about a knapsack problem solution
+type KnapsackSolution struct {
+	Value float64
+	// Counts is how many copies of each item are taken
+	Counts []int
+	// Table[k][c] is the best value using first k items with capacity c
+	Table matrix.Matrix
+}
+
+// Knapsack01 solves 0/1 knapsack problem: every item is taken at most once
+func Knapsack01(items []Item, capacity int) (KnapsackSolution, error) {
+	return knapsack(items, capacity, func(Item) int { return 1 })
+}
+
+// KnapsackBounded solves bounded knapsack problem: item can be taken at most Item.Count times
+func KnapsackBounded(items []Item, capacity int) (KnapsackSolution, error) {
+	return knapsack(items, capacity, func(item Item) int { return item.Count })
+}
+
+// KnapsackUnbounded solves unbounded knapsack problem: item can be taken any number of times
+func KnapsackUnbounded(items []Item, capacity int) (KnapsackSolution, error) {
+	return knapsack(items, capacity, func(item Item) int { return capacity / item.Weight })
+}
+
+func knapsack(items []Item, capacity int, limit func(Item) int) (KnapsackSolution, error) {
+	if capacity < 0 {
+		return KnapsackSolution{}, ErrNegativeCapacity
+	}
+
+	for _, item := range items {
+		if item.Weight <= 0 {
+			return KnapsackSolution{}, ErrInvalidWeight
+		}
+	}
+
+	n := len(items)
+	table := matrix.ShellM(capacity+1, n+1)
+	taken := make([][]int, n+1)
+	taken[0] = make([]int, capacity+1)
+
+	for k := 1; k <= n; k++ {
+		item := items[k-1]
+		maxCount := limit(item)
+		taken[k] = make([]int, capacity+1)
+
+		for c := 0; c <= capacity; c++ {
+			best := table[k-1][c]
+			bestCount := 0
+			for t := 1; t <= maxCount && t*item.Weight <= c; t++ {
+				if value := table[k-1][c-t*item.Weight] + float64(t)*item.Value; value > best {
+					best = value
+					bestCount = t
+				}
+			}
+
+			table[k][c] = best
+			taken[k][c] = bestCount
+		}
+	}
+
+	counts := make([]int, n)
+	c := capacity
+	for k := n; k > 0; k-- {
+		counts[k-1] = taken[k][c]
+		c -= taken[k][c] * items[k-1].Weight
+	}
+
+	return KnapsackSolution{
+		Value:  table[n][capacity],
+		Counts: counts,
+		Table:  table,
+	}, nil
+}
+
+// AllocationSolution contains data about a resource allocation solution
+type AllocationSolution struct {
+	Value float64
+	// Allocation is how many resource units each project gets
+	Allocation []int
+	// Table[k][r] is the best profit of first k+1 projects sharing r units,
+	// Choices[k][r] is how many of r units the (k+1)-th project gets then
+	Table   matrix.Matrix
+	Choices [][]int
+}
+
+// Allocate distributes resource units among projects, profits[i][x] is the profit of i-th project given x units.
+// Every profit row must have at least resource+1 values
+func Allocate(profits matrix.Matrix, resource int) (AllocationSolution, error) {
+	if resource < 0 {
+		return AllocationSolution{}, ErrNegativeCapacity
+	}
+
+	if len(profits) == 0 {
+		return AllocationSolution{}, ErrEmpty
+	}
+
+	for _, row := range profits {
+		if len(row) < resource+1 {
+			return AllocationSolution{}, ErrDimensionMismatch
+		}
+	}
+
+	n := len(profits)
+	table := matrix.ShellM(resource+1, n)
+	choices := make([][]int, n)
+
+	for k, row := range profits {
+		choices[k] = make([]int, resource+1)
+
+		for r := 0; r <= resource; r++ {
+			best := math.Inf(-1)
+			for x := 0; x <= r; x++ {
+				value := row[x]
+				if k > 0 {
+					value += table[k-1][r-x]
+				}
+
+				if value > best {
+					best = value
+					choices[k][r] = x
+				}
+			}
+
+			table[k][r] = best
+		}
+	}
+
+	allocation := make([]int, n)
+	r := resource
+	for k := n - 1; k >= 0; k-- {
+		allocation[k] = choices[k][r]
+		r -= allocation[k]
+	}
+
+	return AllocationSolution{
+		Value:      table[n-1][resource],
+		Allocation: allocation,
+		Table:      table,
+		Choices:    choices,
+	}, nil
+}
+
+// RouteSolution contains data about a shortest route by stages
+type RouteSolution struct {
+	Cost float64
+	// Route holds the node chosen at every stage, starting from the first stage
+	Route []int
+	// Costs[k][i] is the cost of the best route from i-th node of k-th stage to the end
+	Costs []matrix.Vector
+}
+
+// ShortestRoute finds the cheapest route through stages, stages[k][i][j] is the cost to go from
+// i-th node of k-th stage to j-th node of the next stage (+Inf if there is no road)
+func ShortestRoute(stages []matrix.Matrix) (RouteSolution, error) {
+	if len(stages) == 0 {
+		return RouteSolution{}, ErrEmpty
+	}
+
+	for k, stage := range stages {
+		if len(stage) == 0 || len(stage[0]) == 0 {
+			return RouteSolution{}, ErrEmpty
+		}
+
+		for _, row := range stage {
+			if len(row) != len(stage[0]) {
+				return RouteSolution{}, ErrDimensionMismatch
+			}
+		}
+
+		if k > 0 && len(stage) != stages[k-1].Width() {
+			return RouteSolution{}, ErrDimensionMismatch
+		}
+	}
+
+	n := len(stages)
+	costs := make([]matrix.Vector, n+1)
+	next := make([][]int, n)
+	costs[n] = matrix.ShellV(stages[n-1].Width())
+
+	for k := n - 1; k >= 0; k-- {
+		costs[k] = matrix.ShellVWithValue(len(stages[k]), math.Inf(1))
+		next[k] = make([]int, len(stages[k]))
+
+		for i, row := range stages[k] {
+			next[k][i] = -1
+			for j, cost := range row {
+				if value := cost + costs[k+1][j]; value < costs[k][i] {
+					costs[k][i] = value
+					next[k][i] = j
+				}
+			}
+		}
+	}
+
+	start := -1
+	for i, cost := range costs[0] {
+		if !math.IsInf(cost, 1) && (start == -1 || cost < costs[0][start]) {
+			start = i
+		}
+	}
+
+	if start == -1 {
+		return RouteSolution{}, ErrNoRoute
+	}
+
+	route := []int{start}
+	for k := 0; k < n; k++ {
+		route = append(route, next[k][route[k]])
+	}
+
+	return RouteSolution{
+		Cost:  costs[0][start],
+		Route: route,
+		Costs: costs,
+	}, nil
+}
+
+func (s KnapsackSolution) String() string {
+	return fmt.Sprintf("table:\n%scounts:\t%v\nvalue:\t%f", s.Table, s.Counts, s.Value)
+}
+
+func (s AllocationSolution) String() string {
+	str := "table:\n"
+	for k, row := range s.Table {
+		for r, value := range row {
+			str += fmt.Sprintf("%6.3f(%d) ", value, s.Choices[k][r])
+		}
+		str += "\n"
+	}
+
+	return str + fmt.Sprintf("allocation:\t%v\nvalue:\t%f", s.Allocation, s.Value)
+}
+
+func (s RouteSolution) String() string {
+	str := "costs to the end:\n"
+	for k, costs := range s.Costs {
+		str += fmt.Sprintf("stage %d:\t%s\n", k+1, costs)
+	}
+
+	str += "route:"
+	for k, node := range s.Route {
+		str += fmt.Sprintf(" %d.%d", k+1, node+1)
+	}
+
+	return str + fmt.Sprintf("\ncost:\t%f", s.Cost)
+}
@@ -0,0 +1,97 @@
+package dynamic
+
+import (
+	"gomo/matrix"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestKnapsack(t *testing.T) {
+	items := []Item{
+		{Name: "a", Weight: 5, Value: 10, Count: 2},
+		{Name: "b", Weight: 4, Value: 40, Count: 1},
+		{Name: "c", Weight: 6, Value: 30, Count: 1},
+		{Name: "d", Weight: 3, Value: 50, Count: 2},
+	}
+
+	tests := []struct {
+		name       string
+		solve      func([]Item, int) (KnapsackSolution, error)
+		wantValue  float64
+		wantCounts []int
+	}{
+		{"0/1", Knapsack01, 90, []int{0, 1, 0, 1}},
+		{"bounded", KnapsackBounded, 140, []int{0, 1, 0, 2}},
+		{"unbounded", KnapsackUnbounded, 150, []int{0, 0, 0, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.solve(items, 10)
+			if err != nil {
+				t.Fatalf("knapsack error = %v", err)
+			}
+			if got.Value != tt.wantValue || !reflect.DeepEqual(got.Counts, tt.wantCounts) {
+				t.Errorf("knapsack = (%v, %v), want (%v, %v)", got.Value, got.Counts, tt.wantValue, tt.wantCounts)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	profits := matrix.Matrix{
+		{0, 4, 6, 7, 8},
+		{0, 2, 5, 8, 9},
+		{0, 3, 4, 5, 6},
+	}
+
+	got, err := Allocate(profits, 4)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+	if got.Value != 12 || !reflect.DeepEqual(got.Allocation, []int{1, 3, 0}) {
+		t.Errorf("Allocate() = (%v, %v), want (12, [1 3 0])", got.Value, got.Allocation)
+	}
+}
+
+func TestShortestRoute(t *testing.T) {
+	inf := math.Inf(1)
+	stages := []matrix.Matrix{
+		{{2, 4, 3}},
+		{{7, 4, 6}, {3, 2, 4}, {4, 1, 5}},
+		{{1, 4}, {6, 3}, {3, 3}},
+		{{3}, {inf}},
+	}
+
+	got, err := ShortestRoute(stages)
+	if err != nil {
+		t.Fatalf("ShortestRoute() error = %v", err)
+	}
+	if got.Cost != 11 {
+		t.Errorf("ShortestRoute() cost = %v, want 11", got.Cost)
+	}
+	if want := []int{0, 1, 0, 0, 0}; !reflect.DeepEqual(got.Route, want) {
+		t.Errorf("ShortestRoute() route = %v, want %v", got.Route, want)
+	}
+}
+
+func TestShortestRouteErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []matrix.Matrix
+		want   error
+	}{
+		{"no stages", nil, ErrEmpty},
+		{"empty stage", []matrix.Matrix{{{1, 2}}, {}}, ErrEmpty},
+		{"empty row", []matrix.Matrix{{{}}}, ErrEmpty},
+		{"ragged stage", []matrix.Matrix{{{1, 2}, {3}}}, ErrDimensionMismatch},
+		{"stages don't fit", []matrix.Matrix{{{1, 2}}, {{1}}}, ErrDimensionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ShortestRoute(tt.stages); err != tt.want {
+				t.Errorf("ShortestRoute() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,497 @@
+package extensive
+
+import (
+	"errors"
+	"fmt"
+	"gomo/game"
+	"gomo/matrix"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrInvalidNode is returned when a node has wrong count of actions, probabilities or payoffs
+	ErrInvalidNode = errors.New("extensive: node doesn't match its kind")
+	// ErrPlayerOutOfRange is returned when a decision node belongs to unknown player
+	ErrPlayerOutOfRange = errors.New("extensive: player is out of range")
+	// ErrInvalidProbabilities is returned when chance probabilities don't make a distribution
+	ErrInvalidProbabilities = errors.New("extensive: chance probabilities must be non-negative and sum to 1")
+	// ErrInconsistentInfoSet is returned when nodes of an information set differ in player or actions
+	ErrInconsistentInfoSet = errors.New("extensive: nodes of an information set must have the same player and actions")
+	// ErrImperfectInformation is returned when backward induction is applied to a game with information sets
+	ErrImperfectInformation = errors.New("extensive: backward induction needs perfect information")
+	// ErrNilNode is returned when the root or a child of the tree is nil
+	ErrNilNode = errors.New("extensive: node is nil")
+	// ErrNoPlayers is returned when the game has no players
+	ErrNoPlayers = errors.New("extensive: game must have players")
+	// ErrNotTwoPlayers is returned when normal form matrices are requested for other than two players
+	ErrNotTwoPlayers = errors.New("extensive: normal form matrices need exactly two players")
+)
+
+// NodeKind shows the kind of a game tree node
+type NodeKind int
+
+const (
+	// NodeDecision is a node where a player chooses an action
+	NodeDecision NodeKind = iota
+	// NodeChance is a node where nature chooses an action randomly
+	NodeChance NodeKind = iota
+	// NodeTerminal is a leaf with the payoffs of the players
+	NodeTerminal NodeKind = iota
+)
+
+func (kind NodeKind) String() string {
+	switch kind {
+	case NodeDecision:
+		return "decision"
+	case NodeChance:
+		return "chance"
+	case NodeTerminal:
+		return "terminal"
+	}
+
+	return "Undefined"
+}
+
+// Node is a node of a game tree
+type Node struct {
+	Kind NodeKind
+	Name string
+	// Player is index of the player choosing at a decision node
+	Player int
+	// InfoSet groups decision nodes the player can't distinguish, empty value means a set of the only node
+	InfoSet string
+	// Actions are labels of the children of a decision or a chance node
+	Actions []string
+	// Probabilities are the probabilities of the children of a chance node
+	Probabilities matrix.Vector
+	Children      []*Node
+	// Payoffs are the payoffs of every player at a terminal node
+	Payoffs matrix.Vector
+}
+
+// Tree is an extensive-form game
+type Tree struct {
+	Root    *Node
+	Players int
+	// infoSets are the information sets of every player in the order of depth-first search
+	infoSets [][]infoSet
+}
+
+// infoSet is an information set, the nodes share the player and the actions
+type infoSet struct {
+	name    string
+	nodes   []*Node
+	actions []string
+}
+
+// Equilibrium is a pure strategy profile with the payoffs of the players
+type Equilibrium struct {
+	Payoffs matrix.Vector
+	// Choices are indexes of the actions chosen at every decision node
+	Choices map[*Node]int
+	// Path are the actions on the equilibrium path, chance nodes have no path beyond them
+	Path []string
+}
+
+// NormalForm is a game converted to normal form: rows are the strategies of the first player,
+// columns are the strategies of the second one
+type NormalForm struct {
+	// Payoffs are the expected payoffs of every player
+	Payoffs    []matrix.Matrix
+	Strategies [][]string
+}
+
+// Decision makes a decision node, an empty info set means the player knows the exact node
+func Decision(name string, player int, infoSet string, actions []string, children ...*Node) *Node {
+	return &Node{Kind: NodeDecision, Name: name, Player: player, InfoSet: infoSet, Actions: actions, Children: children}
+}
+
+// Chance makes a chance node
+func Chance(name string, actions []string, probabilities matrix.Vector, children ...*Node) *Node {
+	return &Node{Kind: NodeChance, Name: name, Actions: actions, Probabilities: probabilities, Children: children}
+}
+
+// Terminal makes a terminal node
+func Terminal(payoffs ...float64) *Node {
+	return &Node{Kind: NodeTerminal, Payoffs: payoffs}
+}
+
+// NewTree checks the nodes and collects the information sets
+func NewTree(root *Node, players int) (Tree, error) {
+	if players < 1 {
+		return Tree{}, ErrNoPlayers
+	}
+
+	t := Tree{Root: root, Players: players, infoSets: make([][]infoSet, players)}
+	if err := t.collect(root); err != nil {
+		return Tree{}, err
+	}
+
+	return t, nil
+}
+
+func (t *Tree) collect(node *Node) error {
+	if node == nil {
+		return ErrNilNode
+	}
+
+	switch node.Kind {
+	case NodeTerminal:
+		if len(node.Payoffs) != t.Players {
+			return ErrInvalidNode
+		}
+		return nil
+	case NodeChance:
+		if len(node.Probabilities) != len(node.Children) || len(node.Actions) != len(node.Children) {
+			return ErrInvalidNode
+		}
+
+		for _, p := range node.Probabilities {
+			if p < 0 {
+				return ErrInvalidProbabilities
+			}
+		}
+
+		if math.Abs(node.Probabilities.Sum()-1) > matrix.Epsilon {
+			return ErrInvalidProbabilities
+		}
+	case NodeDecision:
+		if len(node.Actions) != len(node.Children) || len(node.Children) == 0 {
+			return ErrInvalidNode
+		}
+
+		if node.Player < 0 || node.Player >= t.Players {
+			return ErrPlayerOutOfRange
+		}
+
+		if err := t.addToInfoSet(node); err != nil {
+			return err
+		}
+	default:
+		return ErrInvalidNode
+	}
+
+	for _, child := range node.Children {
+		if err := t.collect(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Tree) addToInfoSet(node *Node) error {
+	if node.InfoSet != "" {
+		for player, sets := range t.infoSets {
+			for i, set := range sets {
+				if set.name != node.InfoSet {
+					continue
+				}
+
+				if player != node.Player || strings.Join(set.actions, "\x00") != strings.Join(node.Actions, "\x00") {
+					return ErrInconsistentInfoSet
+				}
+
+				t.infoSets[player][i].nodes = append(t.infoSets[player][i].nodes, node)
+				return nil
+			}
+		}
+	}
+
+	t.infoSets[node.Player] = append(t.infoSets[node.Player], infoSet{
+		name:    node.InfoSet,
+		nodes:   []*Node{node},
+		actions: node.Actions,
+	})
+
+	return nil
+}
+
+// IsPerfectInformation checks that every information set has the only node
+func (t Tree) IsPerfectInformation() bool {
+	for _, sets := range t.infoSets {
+		for _, set := range sets {
+			if len(set.nodes) > 1 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// BackwardInduction finds a subgame-perfect equilibrium of a perfect-information game,
+// the first action is chosen on ties
+func (t Tree) BackwardInduction() (Equilibrium, error) {
+	if !t.IsPerfectInformation() {
+		return Equilibrium{}, ErrImperfectInformation
+	}
+
+	choices := map[*Node]int{}
+	payoffs := backward(t.Root, choices)
+
+	return Equilibrium{Payoffs: payoffs, Choices: choices, Path: path(t.Root, choices)}, nil
+}
+
+// backward returns the payoffs of the subgame of the node and records the first best action of every decision node
+func backward(node *Node, choices map[*Node]int) matrix.Vector {
+	switch node.Kind {
+	case NodeTerminal:
+		return node.Payoffs
+	case NodeChance:
+		var payoffs matrix.Vector
+		for i, child := range node.Children {
+			childPayoffs := backward(child, choices)
+			if payoffs == nil {
+				payoffs = matrix.ShellV(len(childPayoffs))
+			}
+
+			for player, payoff := range childPayoffs {
+				payoffs[player] += node.Probabilities[i] * payoff
+			}
+		}
+
+		return payoffs
+	}
+
+	children := make([]matrix.Vector, len(node.Children))
+	best := math.Inf(-1)
+	for i, child := range node.Children {
+		children[i] = backward(child, choices)
+		best = math.Max(best, children[i][node.Player])
+	}
+
+	for i, payoffs := range children {
+		if payoffs[node.Player] >= best-matrix.Epsilon {
+			choices[node] = i
+			return payoffs
+		}
+	}
+
+	return nil
+}
+
+// SubgamePerfectEquilibria finds pure subgame-perfect equilibria of a perfect-information game:
+// backward induction branching on every tie. The count of the equilibria grows exponentially with the ties,
+// so at most limit of them are returned, non-positive limit means every equilibrium
+func (t Tree) SubgamePerfectEquilibria(limit int) ([]Equilibrium, error) {
+	if !t.IsPerfectInformation() {
+		return nil, ErrImperfectInformation
+	}
+
+	equilibria := induct(t.Root, limit)
+	for i := range equilibria {
+		equilibria[i].Path = path(t.Root, equilibria[i].Choices)
+	}
+
+	return equilibria, nil
+}
+
+// induct returns the equilibria of the subgame of the node, up to limit of them when the limit is positive
+func induct(node *Node, limit int) []Equilibrium {
+	if node.Kind == NodeTerminal {
+		return []Equilibrium{{Payoffs: node.Payoffs, Choices: map[*Node]int{}}}
+	}
+
+	full := func(count int) bool {
+		return limit > 0 && count >= limit
+	}
+
+	// the combinations of the children equilibria, every equilibrium of the node is made from one of them
+	combinations := [][]Equilibrium{{}}
+	for _, child := range node.Children {
+		childEquilibria := induct(child, limit)
+
+		next := [][]Equilibrium{}
+		for _, combination := range combinations {
+			for _, e := range childEquilibria {
+				if full(len(next)) {
+					break
+				}
+				next = append(next, append(append([]Equilibrium{}, combination...), e))
+			}
+		}
+		combinations = next
+	}
+
+	equilibria := []Equilibrium{}
+	for _, combination := range combinations {
+		choices := map[*Node]int{}
+		for _, e := range combination {
+			for n, choice := range e.Choices {
+				choices[n] = choice
+			}
+		}
+
+		if node.Kind == NodeChance {
+			payoffs := matrix.ShellV(len(combination[0].Payoffs))
+			for i, e := range combination {
+				for player, payoff := range e.Payoffs {
+					payoffs[player] += node.Probabilities[i] * payoff
+				}
+			}
+
+			equilibria = append(equilibria, Equilibrium{Payoffs: payoffs, Choices: choices})
+			continue
+		}
+
+		best := combination[0].Payoffs[node.Player]
+		for _, e := range combination[1:] {
+			best = math.Max(best, e.Payoffs[node.Player])
+		}
+
+		for i, e := range combination {
+			if e.Payoffs[node.Player] < best-matrix.Epsilon {
+				continue
+			}
+
+			if full(len(equilibria)) {
+				return equilibria
+			}
+
+			withChoice := map[*Node]int{node: i}
+			for n, choice := range choices {
+				withChoice[n] = choice
+			}
+
+			equilibria = append(equilibria, Equilibrium{Payoffs: e.Payoffs, Choices: withChoice})
+		}
+	}
+
+	return equilibria
+}
+
+// path follows the choices from the node until a terminal or a chance node
+func path(node *Node, choices map[*Node]int) []string {
+	actions := []string{}
+	for node.Kind == NodeDecision {
+		choice := choices[node]
+		actions = append(actions, node.Actions[choice])
+		node = node.Children[choice]
+	}
+
+	return actions
+}
+
+// Strategies returns every pure strategy of the player: an action index for every information set of the player
+func (t Tree) Strategies(player int) [][]int {
+	strategies := [][]int{{}}
+	for _, set := range t.infoSets[player] {
+		next := [][]int{}
+		for _, strategy := range strategies {
+			for action := range set.actions {
+				next = append(next, append(append([]int{}, strategy...), action))
+			}
+		}
+		strategies = next
+	}
+
+	return strategies
+}
+
+// strategyName joins the actions of the strategy
+func (t Tree) strategyName(player int, strategy []int) string {
+	actions := make([]string, len(strategy))
+	for i, action := range strategy {
+		actions[i] = t.infoSets[player][i].actions[action]
+	}
+
+	if len(actions) == 0 {
+		return "-"
+	}
+
+	return strings.Join(actions, "/")
+}
+
+// Payoffs returns the expected payoffs of the strategy profile: a strategy for every player
+func (t Tree) Payoffs(profile [][]int) matrix.Vector {
+	choices := map[*Node]int{}
+	for player, strategy := range profile {
+		for i, action := range strategy {
+			for _, node := range t.infoSets[player][i].nodes {
+				choices[node] = action
+			}
+		}
+	}
+
+	return expected(t.Root, choices, t.Players)
+}
+
+func expected(node *Node, choices map[*Node]int, players int) matrix.Vector {
+	switch node.Kind {
+	case NodeTerminal:
+		return node.Payoffs
+	case NodeDecision:
+		return expected(node.Children[choices[node]], choices, players)
+	}
+
+	payoffs := matrix.ShellV(players)
+	for i, child := range node.Children {
+		for player, payoff := range expected(child, choices, players) {
+			payoffs[player] += node.Probabilities[i] * payoff
+		}
+	}
+
+	return payoffs
+}
+
+// NormalForm converts the two-player game to normal form
+func (t Tree) NormalForm() (NormalForm, error) {
+	if t.Players != 2 {
+		return NormalForm{}, ErrNotTwoPlayers
+	}
+
+	strategies1 := t.Strategies(0)
+	strategies2 := t.Strategies(1)
+
+	nf := NormalForm{
+		Payoffs: []matrix.Matrix{
+			matrix.ShellM(len(strategies2), len(strategies1)),
+			matrix.ShellM(len(strategies2), len(strategies1)),
+		},
+		Strategies: [][]string{{}, {}},
+	}
+
+	for y, s1 := range strategies1 {
+		nf.Strategies[0] = append(nf.Strategies[0], t.strategyName(0, s1))
+		for x, s2 := range strategies2 {
+			payoffs := t.Payoffs([][]int{s1, s2})
+			nf.Payoffs[0][y][x] = payoffs[0]
+			nf.Payoffs[1][y][x] = payoffs[1]
+		}
+	}
+
+	for _, s2 := range strategies2 {
+		nf.Strategies[1] = append(nf.Strategies[1], t.strategyName(1, s2))
+	}
+
+	return nf, nil
+}
+
+// Matrix returns the payoffs of the first player, it's the matrix of a zero-sum game for game.SolveGame
+func (nf NormalForm) Matrix() matrix.Matrix {
+	return nf.Payoffs[0]
+}
+
+// Bimatrix returns the bimatrix game of the normal form
+func (nf NormalForm) Bimatrix() (game.Bimatrix, error) {
+	return game.NewBimatrix(nf.Payoffs[0], nf.Payoffs[1])
+}
+
+func (nf NormalForm) String() string {
+	str := ""
+	for y, name := range nf.Strategies[0] {
+		str += fmt.Sprintf("%-10s", name)
+		for x := range nf.Strategies[1] {
+			str += fmt.Sprintf(" (%6.3f, %6.3f)", nf.Payoffs[0][y][x], nf.Payoffs[1][y][x])
+		}
+		str += "\n"
+	}
+
+	return fmt.Sprintf("columns: %s\n", strings.Join(nf.Strategies[1], ", ")) + str
+}
+
+func (e Equilibrium) String() string {
+	return fmt.Sprintf("path: %s\npayoffs: %s", strings.Join(e.Path, " -> "), e.Payoffs)
+}
@@ -0,0 +1,189 @@
+package extensive
+
+import (
+	"gomo/game"
+	"gomo/matrix"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// entryGame is the entry deterrence game: the entrant stays out or enters, the incumbent fights or accommodates
+func entryGame() *Node {
+	return Decision("entrant", 0, "", []string{"out", "in"},
+		Terminal(0, 2),
+		Decision("incumbent", 1, "", []string{"fight", "accommodate"},
+			Terminal(-1, -1),
+			Terminal(1, 1),
+		),
+	)
+}
+
+func TestBackwardInduction(t *testing.T) {
+	tree, err := NewTree(entryGame(), 2)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	got, err := tree.BackwardInduction()
+	if err != nil {
+		t.Fatalf("BackwardInduction() error = %v", err)
+	}
+	if want := []string{"in", "accommodate"}; !reflect.DeepEqual(got.Path, want) {
+		t.Errorf("BackwardInduction() path = %v, want %v", got.Path, want)
+	}
+	if want := (matrix.Vector{1, 1}); !reflect.DeepEqual(got.Payoffs, want) {
+		t.Errorf("BackwardInduction() payoffs = %v, want %v", got.Payoffs, want)
+	}
+}
+
+func TestSubgamePerfectEquilibria(t *testing.T) {
+	// the second player is indifferent, so both choices make an equilibrium
+	root := Decision("first", 0, "", []string{"a", "b"},
+		Decision("second", 1, "", []string{"c", "d"},
+			Terminal(3, 1),
+			Terminal(0, 1),
+		),
+		Terminal(1, 0),
+	)
+
+	tree, err := NewTree(root, 2)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	got, err := tree.SubgamePerfectEquilibria(0)
+	if err != nil {
+		t.Fatalf("SubgamePerfectEquilibria() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("SubgamePerfectEquilibria() = %v, want 2 equilibria", got)
+	}
+	if want := []string{"a", "c"}; !reflect.DeepEqual(got[0].Path, want) {
+		t.Errorf("SubgamePerfectEquilibria() first path = %v, want %v", got[0].Path, want)
+	}
+	if want := []string{"b"}; !reflect.DeepEqual(got[1].Path, want) {
+		t.Errorf("SubgamePerfectEquilibria() second path = %v, want %v", got[1].Path, want)
+	}
+
+	// every node of the full binary tree with zero payoffs is a tie, there are 2^(2^depth - 1) equilibria
+	var binary func(depth int) *Node
+	binary = func(depth int) *Node {
+		if depth == 0 {
+			return Terminal(0, 0)
+		}
+		return Decision("", depth%2, "", []string{"l", "r"}, binary(depth-1), binary(depth-1))
+	}
+
+	tree, _ = NewTree(binary(12), 2)
+	if got, err := tree.BackwardInduction(); err != nil || len(got.Path) != 12 || len(got.Choices) != 1<<12-1 {
+		t.Errorf("BackwardInduction() = %v, %v, want the path of 12 actions", got.Path, err)
+	}
+	if got, err := tree.SubgamePerfectEquilibria(5); err != nil || len(got) != 5 {
+		t.Errorf("SubgamePerfectEquilibria(5) = %d equilibria, %v, want 5", len(got), err)
+	}
+}
+
+func TestNormalForm(t *testing.T) {
+	tree, _ := NewTree(entryGame(), 2)
+
+	nf, err := tree.NormalForm()
+	if err != nil {
+		t.Fatalf("NormalForm() error = %v", err)
+	}
+	if want := (matrix.Matrix{{0, 0}, {-1, 1}}); !reflect.DeepEqual(nf.Matrix(), want) {
+		t.Errorf("NormalForm() = %v, want %v", nf.Matrix(), want)
+	}
+
+	g, err := nf.Bimatrix()
+	if err != nil {
+		t.Fatalf("Bimatrix() error = %v", err)
+	}
+	if got := g.PureEquilibria(); len(got) != 2 {
+		t.Errorf("PureEquilibria() = %v, want 2 equilibria", got)
+	}
+}
+
+func TestImperfectInformation(t *testing.T) {
+	// matching pennies: the second player doesn't see the coin of the first one
+	root := Decision("first", 0, "", []string{"H", "T"},
+		Decision("second after H", 1, "second", []string{"h", "t"},
+			Terminal(1, -1),
+			Terminal(-1, 1),
+		),
+		Decision("second after T", 1, "second", []string{"h", "t"},
+			Terminal(-1, 1),
+			Terminal(1, -1),
+		),
+	)
+
+	tree, err := NewTree(root, 2)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	if _, err := tree.BackwardInduction(); err != ErrImperfectInformation {
+		t.Errorf("BackwardInduction() error = %v, want %v", err, ErrImperfectInformation)
+	}
+
+	nf, _ := tree.NormalForm()
+	if want := (matrix.Matrix{{1, -1}, {-1, 1}}); !reflect.DeepEqual(nf.Matrix(), want) {
+		t.Fatalf("NormalForm() = %v, want %v", nf.Matrix(), want)
+	}
+
+	s, err := game.SolveGame(nf.Matrix())
+	if err != nil {
+		t.Fatalf("SolveGame() error = %v", err)
+	}
+	if math.Abs(s.Cost()) > 1e-9 {
+		t.Errorf("SolveGame() cost = %v, want 0", s.Cost())
+	}
+}
+
+func TestChance(t *testing.T) {
+	root := Chance("coin", []string{"heads", "tails"}, matrix.Vector{0.25, 0.75},
+		Decision("after heads", 0, "", []string{"x", "y"}, Terminal(4, 0), Terminal(0, 0)),
+		Terminal(2, 0),
+	)
+
+	tree, err := NewTree(root, 2)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	got, err := tree.BackwardInduction()
+	if err != nil {
+		t.Fatalf("BackwardInduction() error = %v", err)
+	}
+	if want := (matrix.Vector{2.5, 0}); !reflect.DeepEqual(got.Payoffs, want) {
+		t.Errorf("BackwardInduction() payoffs = %v, want %v", got.Payoffs, want)
+	}
+}
+
+func TestNewTree(t *testing.T) {
+	tests := []struct {
+		name string
+		root *Node
+		want error
+	}{
+		{"payoffs", Decision("a", 0, "", []string{"x"}, Terminal(1)), ErrInvalidNode},
+		{"player", Decision("a", 2, "", []string{"x"}, Terminal(1, 1)), ErrPlayerOutOfRange},
+		{"probabilities", Chance("c", []string{"x", "y"}, matrix.Vector{0.5, 0.6}, Terminal(1, 1), Terminal(1, 1)), ErrInvalidProbabilities},
+		{"info set", Decision("a", 0, "s", []string{"x"},
+			Decision("b", 0, "s", []string{"y"}, Terminal(1, 1)),
+		), ErrInconsistentInfoSet},
+		{"nil root", nil, ErrNilNode},
+		{"nil child", Decision("a", 0, "", []string{"x", "y"}, Terminal(1, 1), nil), ErrNilNode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTree(tt.root, 2); err != tt.want {
+				t.Errorf("NewTree() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewTree(Terminal(), 0); err != ErrNoPlayers {
+		t.Errorf("NewTree() error = %v, want %v", err, ErrNoPlayers)
+	}
+}
@@ -0,0 +1,374 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"gomo/matrix"
+	"math"
+)
+
+var (
+	// ErrDimensionMismatch is returned when payoff matrices have different sizes
+	ErrDimensionMismatch = errors.New("game: payoff matrices must have the same size")
+	// ErrLabelOutOfRange is returned when Lemke-Howson starting label is not a strategy
+	ErrLabelOutOfRange = errors.New("game: label must be in range [0, m+n)")
+	// ErrCycling is returned when Lemke-Howson pivoting doesn't finish on a degenerate game
+	ErrCycling = errors.New("game: Lemke-Howson pivoting cycles, the game is degenerate")
+)
+
+// Bimatrix is a non-zero-sum game, a contains payoffs of the first (row) player, b of the second one
+type Bimatrix struct {
+	a matrix.Matrix
+	b matrix.Matrix
+}
+
+// Equilibrium is a Nash equilibrium of a bimatrix game
+type Equilibrium struct {
+	probabilities1 matrix.Vector
+	probabilities2 matrix.Vector
+	payoff1        float64
+	payoff2        float64
+}
+
+// NewBimatrix makes a bimatrix game checking sizes of the payoff matrices
+func NewBimatrix(a, b matrix.Matrix) (Bimatrix, error) {
+	if len(a) == 0 || len(a[0]) == 0 || len(b) == 0 || len(b[0]) == 0 {
+		return Bimatrix{}, ErrEmpty
+	}
+
+	wa, ha := a.Size()
+	wb, hb := b.Size()
+	if wa != wb || ha != hb {
+		return Bimatrix{}, ErrDimensionMismatch
+	}
+
+	return Bimatrix{a: a, b: b}, nil
+}
+
+// A returns payoffs of the first (row) player
+func (g Bimatrix) A() matrix.Matrix {
+	return g.a
+}
+
+// B returns payoffs of the second (column) player
+func (g Bimatrix) B() matrix.Matrix {
+	return g.b
+}
+
+// Probabilities1 returns mixed strategy of the first (row) player
+func (e Equilibrium) Probabilities1() matrix.Vector {
+	return e.probabilities1
+}
+
+// Probabilities2 returns mixed strategy of the second (column) player
+func (e Equilibrium) Probabilities2() matrix.Vector {
+	return e.probabilities2
+}
+
+// Payoff1 returns expected payoff of the first player
+func (e Equilibrium) Payoff1() float64 {
+	return e.payoff1
+}
+
+// Payoff2 returns expected payoff of the second player
+func (e Equilibrium) Payoff2() float64 {
+	return e.payoff2
+}
+
+// expectedPayoff returns p^T * m * q
+func expectedPayoff(m matrix.Matrix, probabilities1, probabilities2 matrix.Vector) float64 {
+	payoff := 0.0
+	for y, row := range m {
+		payoff += probabilities1[y] * row.MultiplyElementByElement(probabilities2).Sum()
+	}
+
+	return payoff
+}
+
+// newEquilibrium calculates payoffs of the players for the strategies
+func (g Bimatrix) newEquilibrium(probabilities1, probabilities2 matrix.Vector) Equilibrium {
+	return Equilibrium{
+		probabilities1: probabilities1,
+		probabilities2: probabilities2,
+		payoff1:        expectedPayoff(g.a, probabilities1, probabilities2),
+		payoff2:        expectedPayoff(g.b, probabilities1, probabilities2),
+	}
+}
+
+// PureEquilibria finds every equilibrium in pure strategies: cells where both strategies are best responses
+func (g Bimatrix) PureEquilibria() []Equilibrium {
+	w, h := g.a.Size()
+
+	equilibria := []Equilibrium{}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			bestRow := extremeIndexes(g.a.GetColumn(x), true).value
+			bestColumn := extremeIndexes(g.b[y], true).value
+			if g.a[y][x] < bestRow-matrix.Epsilon || g.b[y][x] < bestColumn-matrix.Epsilon {
+				continue
+			}
+
+			probabilities1 := matrix.ShellV(h)
+			probabilities1[y] = 1
+			probabilities2 := matrix.ShellV(w)
+			probabilities2[x] = 1
+
+			equilibria = append(equilibria, g.newEquilibrium(probabilities1, probabilities2))
+		}
+	}
+
+	return equilibria
+}
+
+// SupportEnumeration finds equilibria checking every pair of equal-sized supports.
+// Every equilibrium of a nondegenerate game is found, the method is exponential so it's for small games only
+func (g Bimatrix) SupportEnumeration() []Equilibrium {
+	w, h := g.a.Size()
+	bt := g.b.Transpose()
+
+	equilibria := []Equilibrium{}
+	for k := 1; k <= w && k <= h; k++ {
+		for _, rows := range subsets(h, k) {
+			for _, columns := range subsets(w, k) {
+				// q makes the first player indifferent among the rows of the support and vice versa
+				probabilities2, ok := indifferentStrategy(g.a, rows, columns, w)
+				if !ok {
+					continue
+				}
+
+				probabilities1, ok := indifferentStrategy(bt, columns, rows, h)
+				if !ok {
+					continue
+				}
+
+				if !isBestResponse(g.a, probabilities2, rows) || !isBestResponse(bt, probabilities1, columns) {
+					continue
+				}
+
+				e := g.newEquilibrium(probabilities1, probabilities2)
+				if !containsEquilibrium(equilibria, e) {
+					equilibria = append(equilibria, e)
+				}
+			}
+		}
+	}
+
+	return equilibria
+}
+
+// indifferentStrategy finds a strategy on the columns making the rows equally good for the opponent
+func indifferentStrategy(m matrix.Matrix, rows, columns []int, width int) (matrix.Vector, bool) {
+	k := len(columns)
+
+	// unknowns are the probabilities of the columns and the payoff u
+	system := matrix.ShellM(k+1, k+1)
+	for i, y := range rows {
+		for j, x := range columns {
+			system[i][j] = m[y][x]
+		}
+		system[i][k] = -1
+	}
+	for j := 0; j < k; j++ {
+		system[k][j] = 1
+	}
+	right := matrix.ShellV(k + 1)
+	right[k] = 1
+
+	// the singular system has no unique indifferent strategy, the ill-conditioned solution is checked later
+	solution, err := system.Solve(right)
+	if err != nil && err != matrix.ErrIllConditioned {
+		return nil, false
+	}
+
+	probabilities := matrix.ShellV(width)
+	for j, x := range columns {
+		if solution[j] < -matrix.Epsilon {
+			return nil, false
+		}
+		probabilities[x] = math.Max(solution[j], 0)
+	}
+
+	return probabilities, true
+}
+
+// isBestResponse checks that the support rows give the maximal payoff against the opponent's strategy
+func isBestResponse(m matrix.Matrix, probabilities2 matrix.Vector, support []int) bool {
+	payoffs := matrix.ShellV(len(m))
+	for y, row := range m {
+		payoffs[y] = row.MultiplyElementByElement(probabilities2).Sum()
+	}
+
+	best := extremeIndexes(payoffs, true).value
+	for _, y := range support {
+		if payoffs[y] < best-matrix.Epsilon {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsEquilibrium(equilibria []Equilibrium, e Equilibrium) bool {
+	same := func(v1, v2 matrix.Vector) bool {
+		for i := range v1 {
+			if math.Abs(v1[i]-v2[i]) > 1e-6 {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, other := range equilibria {
+		if same(other.probabilities1, e.probabilities1) && same(other.probabilities2, e.probabilities2) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subsets returns every k-element subset of [0, n) in lexicographic order
+func subsets(n, k int) [][]int {
+	result := [][]int{}
+
+	var walk func(start int, current []int)
+	walk = func(start int, current []int) {
+		if len(current) == k {
+			result = append(result, append([]int{}, current...))
+			return
+		}
+
+		for i := start; i <= n-(k-len(current)); i++ {
+			walk(i+1, append(current, i))
+		}
+	}
+	walk(0, []int{})
+
+	return result
+}
+
+// LemkeHowson finds one equilibrium with the Lemke-Howson algorithm starting by dropping provided label.
+// Labels [0, m) are the rows and labels [m, m+n) are the columns
+func (g Bimatrix) LemkeHowson(label int) (Equilibrium, error) {
+	w, h := g.a.Size()
+	if label < 0 || label >= w+h {
+		return Equilibrium{}, ErrLabelOutOfRange
+	}
+
+	// payoffs are made positive, it doesn't change equilibria
+	shift := 1 - math.Min(g.a.Min(), g.b.Min())
+
+	// the column of every tableau is the label of its variable, the last column is the right side;
+	// the row player's strategy lives in the tableau of B^T, the column player's one in the tableau of A
+	rowTableau := newTableau(w, h, func(y, x int) float64 { return g.b[x][y] + shift }, false)
+	columnTableau := newTableau(h, w, func(y, x int) float64 { return g.a[y][x] + shift }, true)
+
+	tableau := &rowTableau
+	if label >= h {
+		tableau = &columnTableau
+	}
+
+	entering := label
+	for i := 0; ; i++ {
+		if i > 1000*(w+h) {
+			return Equilibrium{}, ErrCycling
+		}
+
+		dropped, ok := tableau.pivot(entering)
+		if !ok {
+			return Equilibrium{}, ErrCycling
+		}
+
+		if tableau == &rowTableau {
+			tableau = &columnTableau
+		} else {
+			tableau = &rowTableau
+		}
+
+		if dropped == label {
+			break
+		}
+		entering = dropped
+	}
+
+	return g.newEquilibrium(rowTableau.strategy(0, h), columnTableau.strategy(h, w)), nil
+}
+
+// lhTableau is a Lemke-Howson tableau: the rows with their basic variables
+type lhTableau struct {
+	rows  matrix.Matrix
+	basis []int
+}
+
+// newTableau makes the tableau of a best response polytope: the payoffs and a slack variable for every row.
+// slacksFirst is set when the slacks take the first labels and the variables the following ones
+func newTableau(height, variables int, payoff func(y, x int) float64, slacksFirst bool) lhTableau {
+	total := height + variables
+	t := lhTableau{rows: matrix.ShellM(total+1, height), basis: make([]int, height)}
+
+	for y := 0; y < height; y++ {
+		offset, slack := 0, variables+y
+		if slacksFirst {
+			offset, slack = height, y
+		}
+
+		for x := 0; x < variables; x++ {
+			t.rows[y][offset+x] = payoff(y, x)
+		}
+		t.rows[y][slack] = 1
+		t.rows[y][total] = 1
+		t.basis[y] = slack
+	}
+
+	return t
+}
+
+// pivot enters the variable with provided label using the minimum ratio test and returns the dropped label
+func (t *lhTableau) pivot(entering int) (int, bool) {
+	last := t.rows.Width() - 1
+
+	pivot := -1
+	for y, row := range t.rows {
+		if row[entering] <= matrix.Epsilon {
+			continue
+		}
+
+		if pivot == -1 || row[last]/row[entering] < t.rows[pivot][last]/t.rows[pivot][entering]-matrix.Epsilon {
+			pivot = y
+		}
+	}
+
+	if pivot == -1 {
+		return 0, false
+	}
+
+	t.rows = t.rows.DivideRow(pivot, t.rows[pivot][entering])
+	for y := range t.rows {
+		if y != pivot && t.rows[y][entering] != 0 {
+			t.rows = t.rows.SubstractRow(pivot, y, t.rows[y][entering])
+		}
+	}
+
+	dropped := t.basis[pivot]
+	t.basis[pivot] = entering
+
+	return dropped, true
+}
+
+// strategy reads normalized values of the variables with labels [from, from+count)
+func (t lhTableau) strategy(from, count int) matrix.Vector {
+	last := t.rows.Width() - 1
+
+	probabilities := matrix.ShellV(count)
+	for y, label := range t.basis {
+		if label >= from && label < from+count {
+			probabilities[label-from] = t.rows[y][last]
+		}
+	}
+
+	return probabilities.MultiplyWithNumber(1 / probabilities.Sum())
+}
+
+func (e Equilibrium) String() string {
+	return fmt.Sprintf("ps1: %s\nps2: %s\npayoff1: %f\npayoff2: %f", e.probabilities1, e.probabilities2, e.payoff1, e.payoff2)
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"gomo/matrix"
+	"math"
+)
+
+// maxBrownRobinsonIterations limits the method when only the tolerance stops it
+const maxBrownRobinsonIterations = 100000
+
+var (
+	// ErrNoStopCondition is returned when neither iterations count nor tolerance is positive
+	ErrNoStopCondition = errors.New("game: iterations count or tolerance must be positive")
+	// ErrNotConverged is returned when the gap isn't within the tolerance after the maximal count of iterations
+	ErrNotConverged = errors.New("game: Brown-Robinson method didn't reach the tolerance")
+)
+
+// BrownRobinsonStep is one row of the Brown-Robinson (fictitious play) table
+type BrownRobinsonStep struct {
+	k      int
+	row    int
+	column int
+	// gains are the first player's cumulative payoffs of every row against the second player's choices
+	gains matrix.Vector
+	// losses are the second player's cumulative payoffs of every column against the first player's choices
+	losses matrix.Vector
+	upper  float64
+	lower  float64
+}
+
+// SolveGameBrownRobinson approximately solves the game with the Brown-Robinson method.
+// It stops after provided iterations count or when the gap between the best upper and lower values
+// is not bigger than tolerance (non-positive value disables the condition).
+// Without iterations count the method makes at most maxBrownRobinsonIterations steps.
+// onStep gets every row of the table when it isn't nil, so the table is kept only by the callers who need it
+func SolveGameBrownRobinson(m matrix.Matrix, iterations int, tolerance float64, onStep func(BrownRobinsonStep)) (Solution, error) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return Solution{}, ErrEmpty
+	}
+
+	if iterations <= 0 && tolerance <= 0 {
+		return Solution{}, ErrNoStopCondition
+	}
+
+	isLimited := iterations > 0
+	if !isLimited {
+		iterations = maxBrownRobinsonIterations
+	}
+
+	w, h := m.Size()
+	gains := matrix.ShellV(h)
+	losses := matrix.ShellV(w)
+	rowCounts := matrix.ShellV(h)
+	columnCounts := matrix.ShellV(w)
+
+	bestUpper := math.Inf(1)
+	bestLower := math.Inf(-1)
+
+	// the first player starts with a maximin strategy
+	row := GetBounds(m).lower.indexes[0]
+	steps := 0
+	converged := false
+
+	for k := 1; k <= iterations; k++ {
+		steps = k
+
+		rowCounts[row]++
+		for x, value := range m[row] {
+			losses[x] += value
+		}
+
+		column := extremeIndexes(losses, false).indexes[0]
+		columnCounts[column]++
+		for y := range gains {
+			gains[y] += m[y][column]
+		}
+
+		upper := extremeIndexes(gains, true).value / float64(k)
+		lower := extremeIndexes(losses, false).value / float64(k)
+		bestUpper = math.Min(bestUpper, upper)
+		bestLower = math.Max(bestLower, lower)
+
+		if onStep != nil {
+			onStep(BrownRobinsonStep{
+				k:      k,
+				row:    row,
+				column: column,
+				gains:  gains.Clone(),
+				losses: losses.Clone(),
+				upper:  upper,
+				lower:  lower,
+			})
+		}
+
+		if tolerance > 0 && bestUpper-bestLower <= tolerance {
+			converged = true
+			break
+		}
+
+		row = extremeIndexes(gains, true).indexes[0]
+	}
+
+	if !isLimited && !converged {
+		return Solution{}, ErrNotConverged
+	}
+
+	k := float64(steps)
+
+	return Solution{
+		probabilities1: rowCounts.MultiplyWithNumber(1 / k),
+		probabilities2: columnCounts.MultiplyWithNumber(1 / k),
+		cost:           (bestUpper + bestLower) / 2,
+		bounds:         GetBounds(m),
+	}, nil
+}
+
+// K returns the number of the step
+func (step BrownRobinsonStep) K() int {
+	return step.k
+}
+
+// Row returns the strategy chosen by the first player
+func (step BrownRobinsonStep) Row() int {
+	return step.row
+}
+
+// Column returns the strategy chosen by the second player
+func (step BrownRobinsonStep) Column() int {
+	return step.column
+}
+
+// Gains returns the first player's cumulative payoffs of every row
+func (step BrownRobinsonStep) Gains() matrix.Vector {
+	return step.gains
+}
+
+// Losses returns the second player's cumulative payoffs of every column
+func (step BrownRobinsonStep) Losses() matrix.Vector {
+	return step.losses
+}
+
+// Upper returns the upper estimation of the value of the game at the step
+func (step BrownRobinsonStep) Upper() float64 {
+	return step.upper
+}
+
+// Lower returns the lower estimation of the value of the game at the step
+func (step BrownRobinsonStep) Lower() float64 {
+	return step.lower
+}
+
+// BrownRobinsonTable stringifies the steps as a table, only the header is returned when there are no steps
+func BrownRobinsonTable(steps []BrownRobinsonStep) string {
+	rows, columns := 0, 0
+	if len(steps) > 0 {
+		rows, columns = len(steps[0].gains), len(steps[0].losses)
+	}
+
+	str := fmt.Sprintf("%4s %4s %4s | %-*s | %-*s | %6s %6s\n", "k", "i", "j", rows*7, "gains", columns*7, "losses", "upper", "lower")
+	for _, step := range steps {
+		str += step.String() + "\n"
+	}
+
+	return str
+}
+
+func (step BrownRobinsonStep) String() string {
+	return fmt.Sprintf("%4d %4d %4d | %s| %s| %6.3f %6.3f", step.k, step.row+1, step.column+1, step.gains, step.losses, step.upper, step.lower)
+}
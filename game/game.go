@@ -1,22 +1,32 @@
 package game
 
 import (
+	"errors"
 	"fmt"
 	"gomo/lpt"
 	"gomo/matrix"
 	"math"
 )
 
-// Bound contains index and value
+var (
+	// ErrEmpty is returned when the game matrix is empty
+	ErrEmpty = errors.New("game: matrix is empty")
+	// ErrNoSolution is returned when the simplex method doesn't find an optimum
+	ErrNoSolution = errors.New("game: simplex method failed to find a solution")
+	// ErrVerification is returned when the found strategies don't hold the value of the game
+	ErrVerification = errors.New("game: solution doesn't pass verification")
+)
+
+// Bound contains value and indexes of strategies achieving it
 type Bound struct {
-	index int
-	value float64
+	indexes []int
+	value   float64
 }
 
-// Bounds contains top and bottom bound
+// Bounds contains lower (maximin) and upper (minimax) values of a game
 type Bounds struct {
-	topBound    Bound
-	bottomBound Bound
+	lower Bound
+	upper Bound
 }
 
 // Solution contains data about a game solution
@@ -27,168 +37,182 @@ type Solution struct {
 	bounds         Bounds
 }
 
+// Value returns the bound value
+func (b Bound) Value() float64 {
+	return b.value
+}
+
+// Indexes returns indexes of strategies achieving the bound
+func (b Bound) Indexes() []int {
+	return b.indexes
+}
+
 // String stringifies provided bound
 func (b Bound) String() string {
-	return fmt.Sprintf("index: %d, value: %f", b.index, b.value)
+	return fmt.Sprintf("indexes: %v, value: %f", b.indexes, b.value)
 }
 
-// String stringifies provided bounds
-func (bs Bounds) String() string {
-	return fmt.Sprintf("top:\t(%s)\nbottom:\t(%s)", bs.topBound, bs.bottomBound)
+// Lower returns lower value of the game (maximin) and the rows achieving it
+func (bs Bounds) Lower() Bound {
+	return bs.lower
 }
 
-func (s Solution) String() string {
-	return fmt.Sprintf("ps1:\t[%s]\nps2:\t[%s]\ncost:\t%f\nbounds:\n%s", s.probabilities1, s.probabilities2, s.cost, s.bounds)
+// Upper returns upper value of the game (minimax) and the columns achieving it
+func (bs Bounds) Upper() Bound {
+	return bs.upper
 }
 
-// GetBounds calcs alpha and betta bounds
-func GetBounds(m matrix.Matrix) Bounds {
-	// w := m.Width() + 1
-	// h := m.Height() + 1
-	// mAug := matrix.ShellM(w, h).FillWith(m)
+// HasSaddlePoint checks if lower and upper values are equal
+func (bs Bounds) HasSaddlePoint() bool {
+	return math.Abs(bs.upper.value-bs.lower.value) < matrix.Epsilon
+}
 
-	// for _, row := range mAug {
-	// 	row[w - 1] = row.Max()
-	// }
+// SaddlePoints returns every saddle point as [row, column] pair
+func (bs Bounds) SaddlePoints() [][2]int {
+	if !bs.HasSaddlePoint() {
+		return nil
+	}
 
-	// for x := 0; x < w; x++ {
-	// 	column := m.GetColumn(x)
-	// 	mAug[h - 1][x] = column.Max()
-	// }
+	points := [][2]int{}
+	for _, y := range bs.lower.indexes {
+		for _, x := range bs.upper.indexes {
+			points = append(points, [2]int{y, x})
+		}
+	}
 
-	w := m.Width()
+	return points
+}
 
-	alphaIndex := -1
-	alphaValue := -math.MaxFloat64
-	for y, row := range m {
-		v := row.Max()
-		if v > alphaValue {
-			alphaIndex = y
-			alphaValue = v
-		}
+// String stringifies provided bounds
+func (bs Bounds) String() string {
+	str := fmt.Sprintf("lower:\t(%s)\nupper:\t(%s)", bs.lower, bs.upper)
+	if bs.HasSaddlePoint() {
+		str += fmt.Sprintf("\nsaddle points:\t%v", bs.SaddlePoints())
 	}
 
-	betaIndex := -1
-	betaValue := math.MaxFloat64
-	for x := 0; x < w; x++ {
-		column := m.GetColumn(x)
-		v := column.Max()
+	return str
+}
 
-		if v < betaValue {
-			betaIndex = x
-			betaValue = v
+// Probabilities1 returns mixed strategy of the first (row) player
+func (s Solution) Probabilities1() matrix.Vector {
+	return s.probabilities1
+}
 
-			if betaIndex == alphaIndex {
-				break
-			}
-		}
-	}
+// Probabilities2 returns mixed strategy of the second (column) player
+func (s Solution) Probabilities2() matrix.Vector {
+	return s.probabilities2
+}
 
-	return Bounds{
-		Bound{
-			alphaIndex,
-			alphaValue,
-		},
-		Bound{
-			betaIndex,
-			betaValue,
-		},
-	}
+// Cost returns value of the game
+func (s Solution) Cost() float64 {
+	return s.cost
 }
 
-// Simplify simplifies given matrix
-func Simplify(m matrix.Matrix) matrix.Matrix {
-	w, h := m.Size()
-	removedColumns := matrix.ShellV(w)
-	removedRows := matrix.ShellV(h)
+// Bounds returns lower and upper values of the game
+func (s Solution) Bounds() Bounds {
+	return s.bounds
+}
+
+func (s Solution) String() string {
+	return fmt.Sprintf("ps1:\t[%s]\nps2:\t[%s]\ncost:\t%f\nbounds:\n%s", s.probabilities1, s.probabilities2, s.cost, s.bounds)
+}
 
-	for y1, row1 := range m {
-		if removedRows[y1] == 1 {
+// extremeIndexes returns the biggest (or the smallest if max is false) value and every index achieving it
+func extremeIndexes(values matrix.Vector, max bool) Bound {
+	b := Bound{}
+	for i, v := range values {
+		if len(b.indexes) != 0 && math.Abs(v-b.value) < matrix.Epsilon {
+			b.indexes = append(b.indexes, i)
 			continue
 		}
 
-		for y2, row2 := range m {
-			if removedRows[y2] == 1 || y2 == y1 {
-				continue
-			}
-
-			everyIsLess := true
-			for x, el1 := range row1 {
-				everyIsLess = everyIsLess && (removedColumns[x] == 1 || el1 >= row2[x])
-			}
-
-			if everyIsLess {
-				removedRows[y2] = 1
-			}
+		if len(b.indexes) == 0 || (max && v > b.value) || (!max && v < b.value) {
+			b.indexes = []int{i}
+			b.value = v
 		}
 	}
 
-	columns := m.Transpose()
+	return b
+}
 
-	for x1, column1 := range columns {
-		if removedColumns[x1] == 1 {
-			continue
-		}
+// GetBounds calcs lower (alpha = max of row minima) and upper (beta = min of column maxima) bounds
+func GetBounds(m matrix.Matrix) Bounds {
+	w, h := m.Size()
 
-		for x2, column2 := range columns {
-			if removedColumns[x2] == 1 || x2 == x1 {
-				continue
-			}
+	rowMinima := matrix.ShellV(h)
+	for y, row := range m {
+		rowMinima[y] = extremeIndexes(row, false).value
+	}
 
-			everyIsBigger := true
-			for y, el1 := range column1 {
-				everyIsBigger = everyIsBigger && (removedRows[y] == 1 || el1 <= column2[y])
-			}
+	columnMaxima := matrix.ShellV(w)
+	for x := 0; x < w; x++ {
+		columnMaxima[x] = extremeIndexes(m.GetColumn(x), true).value
+	}
 
-			if everyIsBigger {
-				removedRows[x2] = 1
-			}
-		}
+	return Bounds{
+		lower: extremeIndexes(rowMinima, true),
+		upper: extremeIndexes(columnMaxima, false),
 	}
+}
 
-	removedColumnsCount := removedColumns.CountValue(1)
-	removedRowsCount := removedRows.CountValue(1)
+// NewSolution makes solution from the players' mixed strategies, the cost is their expected payoff
+func NewSolution(m matrix.Matrix, probabilities1, probabilities2 matrix.Vector) Solution {
+	cost := 0.0
+	for y, row := range m {
+		cost += probabilities1[y] * row.MultiplyElementByElement(probabilities2).Sum()
+	}
 
-	matrixNew := matrix.ShellM(w-removedColumnsCount, h-removedRowsCount)
+	return Solution{
+		probabilities1: probabilities1,
+		probabilities2: probabilities2,
+		cost:           cost,
+		bounds:         GetBounds(m),
+	}
+}
 
-	realY := 0
-	for y, row := range m {
-		if removedRows[y] == 1 {
-			continue
-		}
+// pureSolution returns solution in pure strategies for the game with a saddle point
+func pureSolution(m matrix.Matrix, bounds Bounds) Solution {
+	w, h := m.Size()
 
-		realX := 0
-		for x, el := range row {
-			if removedColumns[x] == 1 {
-				continue
-			}
+	probabilities1 := matrix.ShellV(h)
+	probabilities1[bounds.lower.indexes[0]] = 1
 
-			matrixNew[realY][realX] = el
-			realX++
-		}
+	probabilities2 := matrix.ShellV(w)
+	probabilities2[bounds.upper.indexes[0]] = 1
 
-		realY++
+	return Solution{
+		probabilities1: probabilities1,
+		probabilities2: probabilities2,
+		cost:           bounds.lower.value,
+		bounds:         bounds,
 	}
+}
 
-	return matrixNew
+// Simplify simplifies given matrix removing weakly dominated rows and columns
+func Simplify(m matrix.Matrix) matrix.Matrix {
+	return Reduce(m, DominanceWeak, false).Matrix()
 }
 
 // SolveGame2x2 solves game2x2
 func SolveGame2x2(m matrix.Matrix) Solution {
+	bounds := GetBounds(m)
+	if bounds.HasSaddlePoint() {
+		return pureSolution(m, bounds)
+	}
+
 	a11 := m[0][0]
 	a21 := m[1][0]
 	a12 := m[0][1]
 	a22 := m[1][1]
 
-	p1 := (a22 - a12) / (a11 - a21 - a12 + a22)
+	// p1 makes the first player indifferent to the columns, q1 makes the second one indifferent to the rows
+	p1 := (a22 - a21) / (a11 - a21 - a12 + a22)
 	p2 := 1 - p1
 
-	q1 := (a22 - a21) / (a11 - a12 - a21 + a22)
+	q1 := (a22 - a12) / (a11 - a12 - a21 + a22)
 	q2 := 1 - q1
 
-	v := p1*a11 + p2*a12
-
-	bounds := GetBounds(m)
+	v := p1*a11 + p2*a21
 
 	solution := Solution{
 		probabilities1: matrix.Vector{p1, p2},
@@ -200,86 +224,79 @@ func SolveGame2x2(m matrix.Matrix) Solution {
 	return solution
 }
 
-// SolveGame solves game mxn
-func SolveGame(m matrix.Matrix) Solution {
-	m = m.Clone().Transpose()
-	minValue := m.Min()
-	wOriginal, hOriginal := m.Size()
-
-	appendix := 0.0
-	if minValue < 0 {
-		appendix = minValue*-1 + 1
+// SolveGame solves game mxn formulating the linear programs of both players.
+// The solution is verified: both strategies must be probability vectors and hold the value of the game
+func SolveGame(m matrix.Matrix) (Solution, error) {
+	return SolveGameOf[matrix.Float64](m)
+}
 
-		for y, row := range m {
-			for x, el := range row {
-				m[y][x] = el + appendix
-			}
-		}
+// SolveGameOf is SolveGame solving the linear programs with the arithmetic of the number type,
+// matrix.Rational finds the exact strategies of the degenerate games
+func SolveGameOf[T matrix.Real[T]](m matrix.Matrix) (Solution, error) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return Solution{}, ErrEmpty
 	}
 
-	lptMatrix := matrix.ShellM(wOriginal+1, hOriginal).FillWith(m)
-	w, _ := lptMatrix.Size()
-
-	for _, row := range lptMatrix {
-		row[w-1] = 1
+	bounds := GetBounds(m)
+	if bounds.HasSaddlePoint() {
+		return pureSolution(m, bounds), nil
 	}
 
-	println("Start matrix:")
-	println(lptMatrix.String())
-	println()
-
-	println("With appendix:")
-	println(lptMatrix.String())
-	println()
-
-	operators := make([]lpt.Operator, len(lptMatrix))
-	for i := range operators {
-		operators[i] = lpt.OperatorLessOrEqual
+	probabilities2, cost, err := solveColumnPlayer[T](m)
+	if err != nil {
+		return Solution{}, err
 	}
 
-	l := lpt.LPT{}.
-		SetMatrix(lptMatrix, operators).
-		SetSignConditionToEvery(lpt.OperatorGreaterOrEqual).
-		SetDefaultTargetFunction()
-
-	println("Resulting LPT:")
-	println(l.String())
-	println()
-
-	ld := l.GenerateDualTask()
-
-	println("Dual LPT:")
-	println(ld.String())
-	println()
-
-	ldc := ld.CanonicalForm()
-
-	println("Canonical LPT:")
-	println(ldc.String())
-	println()
+	// the first player is the column player of the game -A^T
+	probabilities1, _, err := solveColumnPlayer[T](m.Transpose().MultiplyWithNumber(-1))
+	if err != nil {
+		return Solution{}, err
+	}
 
-	ml := ldc.LimitationsAsMatrix().OriginalBaseVector()
-	ldcs, zValues := ldc.SetMatrix(ml).DoSimplex()
-	mlres := ldcs.LimitationsAsMatrix()
+	s := Solution{
+		probabilities1: probabilities1,
+		probabilities2: probabilities2,
+		cost:           cost,
+		bounds:         bounds,
+	}
 
-	println()
-	println(mlres.String())
+	if err := s.Verify(m, lpt.SolutionTolerance); err != nil {
+		return Solution{}, err
+	}
 
-	basis2 := mlres.GetBasis()
-	println(basis2.String())
+	return s, nil
+}
 
-	valuesForY := basis2[:len(zValues)-hOriginal]
-	basis2WithZeros := make(matrix.Vector, len(basis2)).FillWith(valuesForY)
+// Verify checks that the strategies are probability vectors, p^T A >= v and A q <= v within tolerance
+func (s Solution) Verify(m matrix.Matrix, tolerance float64) error {
+	w, h := m.Size()
+	if len(s.probabilities1) != h || len(s.probabilities2) != w {
+		return ErrVerification
+	}
 
-	gameCost := 1 / ldcs.ToLPT().MutliplyTargetFunctionWith(basis2WithZeros).Sum()
+	for _, ps := range []matrix.Vector{s.probabilities1, s.probabilities2} {
+		for _, p := range ps {
+			if p < -tolerance {
+				return ErrVerification
+			}
+		}
 
-	probabilities2 := valuesForY.MultiplyWithNumber(gameCost)
+		if math.Abs(ps.Sum()-1) > tolerance {
+			return ErrVerification
+		}
+	}
 
-	probabilities1 := zValues[len(zValues)-hOriginal : len(zValues)-1].MultiplyWithNumber(gameCost)
+	for x := 0; x < w; x++ {
+		if m.GetColumn(x).MultiplyElementByElement(s.probabilities1).Sum() < s.cost-tolerance {
+			return ErrVerification
+		}
+	}
 
-	return Solution{
-		probabilities1: probabilities1,
-		probabilities2: probabilities2,
-		cost:           gameCost - appendix,
+	for _, row := range m {
+		if row.MultiplyElementByElement(s.probabilities2).Sum() > s.cost+tolerance {
+			return ErrVerification
+		}
 	}
+
+	return nil
 }
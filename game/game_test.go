@@ -0,0 +1,397 @@
+package game
+
+import (
+	"gomo/matrix"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		m          matrix.Matrix
+		wantLower  Bound
+		wantUpper  Bound
+		wantSaddle bool
+	}{
+		{"saddle", matrix.Matrix{
+			{3, 3, 2, 5},
+			{4, 4, 3, 2},
+			{7, 7, 4, 5},
+			{4, 3, 3, 2},
+			{4, 3, 4, 6},
+		}, Bound{[]int{2}, 4}, Bound{[]int{2}, 4}, true},
+		{"several saddles", matrix.Matrix{
+			{1, 1},
+			{1, 1},
+		}, Bound{[]int{0, 1}, 1}, Bound{[]int{0, 1}, 1}, true},
+		{"no saddle", matrix.Matrix{
+			{-6, 1},
+			{3, -7},
+		}, Bound{[]int{0}, -6}, Bound{[]int{1}, 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetBounds(tt.m)
+			if !reflect.DeepEqual(got.Lower(), tt.wantLower) {
+				t.Errorf("GetBounds() lower = %v, want %v", got.Lower(), tt.wantLower)
+			}
+			if !reflect.DeepEqual(got.Upper(), tt.wantUpper) {
+				t.Errorf("GetBounds() upper = %v, want %v", got.Upper(), tt.wantUpper)
+			}
+			if got.HasSaddlePoint() != tt.wantSaddle {
+				t.Errorf("GetBounds() saddle = %v, want %v", got.HasSaddlePoint(), tt.wantSaddle)
+			}
+		})
+	}
+}
+
+func TestSolveGamePure(t *testing.T) {
+	m := matrix.Matrix{
+		{3, 3, 2, 5},
+		{4, 4, 3, 2},
+		{7, 7, 4, 5},
+		{4, 3, 3, 2},
+		{4, 3, 4, 6},
+	}
+
+	got, err := SolveGame(m)
+	if err != nil {
+		t.Fatalf("SolveGame() error = %v", err)
+	}
+	if got.Cost() != 4 {
+		t.Errorf("SolveGame() cost = %v, want 4", got.Cost())
+	}
+	if want := (matrix.Vector{0, 0, 1, 0, 0}); !reflect.DeepEqual(got.Probabilities1(), want) {
+		t.Errorf("SolveGame() ps1 = %v, want %v", got.Probabilities1(), want)
+	}
+	if want := (matrix.Vector{0, 0, 1, 0}); !reflect.DeepEqual(got.Probabilities2(), want) {
+		t.Errorf("SolveGame() ps2 = %v, want %v", got.Probabilities2(), want)
+	}
+}
+
+func almostEqual(v1, v2 matrix.Vector) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+
+	for i := range v1 {
+		if math.Abs(v1[i]-v2[i]) > 1e-9 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestSolveGame(t *testing.T) {
+	third := 1.0 / 3
+	tests := []struct {
+		name  string
+		m     matrix.Matrix
+		want1 matrix.Vector
+		want2 matrix.Vector
+		cost  float64
+	}{
+		{
+			"rock-paper-scissors",
+			matrix.Matrix{{0, -1, 1}, {1, 0, -1}, {-1, 1, 0}},
+			matrix.Vector{third, third, third},
+			matrix.Vector{third, third, third},
+			0,
+		},
+		{
+			"2x3",
+			matrix.Matrix{{2, 3, 11}, {7, 5, 2}},
+			matrix.Vector{3.0 / 11, 8.0 / 11},
+			matrix.Vector{0, 9.0 / 11, 2.0 / 11},
+			49.0 / 11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SolveGame(tt.m)
+			if err != nil {
+				t.Fatalf("SolveGame() error = %v", err)
+			}
+			if !almostEqual(got.Probabilities1(), tt.want1) || !almostEqual(got.Probabilities2(), tt.want2) {
+				t.Errorf("SolveGame() = (%v, %v), want (%v, %v)", got.Probabilities1(), got.Probabilities2(), tt.want1, tt.want2)
+			}
+			if math.Abs(got.Cost()-tt.cost) > 1e-9 {
+				t.Errorf("SolveGame() cost = %v, want %v", got.Cost(), tt.cost)
+			}
+		})
+	}
+
+	// variants 13 and 14 of the scripts have no saddle point, variant 15 is the same as 14
+	for _, m := range []matrix.Matrix{
+		{{-8, -5, 4, 1}, {3, 8, 5, 7}, {5, 3, -8, -9}},
+		{{4, -8, -5, 4}, {-6, 5, 8, 5}, {2, 3, -7, 3}},
+	} {
+		got, err := SolveGame(m)
+		if err != nil {
+			t.Fatalf("SolveGame() error = %v", err)
+		}
+		if err := got.Verify(m, 1e-9); err != nil {
+			t.Errorf("SolveGame() = %v doesn't pass verification", got)
+		}
+	}
+
+	if _, err := SolveGame(matrix.Matrix{}); err != ErrEmpty {
+		t.Errorf("SolveGame() error = %v, want %v", err, ErrEmpty)
+	}
+}
+
+func TestSolveGameOf(t *testing.T) {
+	m := matrix.Matrix{{2, 3, 11}, {7, 5, 2}}
+	want := matrix.Vector{3.0 / 11, 8.0 / 11, 0, 9.0 / 11, 2.0 / 11}
+
+	// the rationals give the nearest floats to the exact strategies
+	tests := []struct {
+		name      string
+		solve     func(matrix.Matrix) (Solution, error)
+		tolerance float64
+	}{
+		{"Rational", SolveGameOf[matrix.Rational], 1e-15},
+		{"Float32", SolveGameOf[matrix.Float32], 1e-6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.solve(m)
+			if err != nil {
+				t.Fatalf("SolveGameOf() error = %v", err)
+			}
+			if err := got.Verify(m, tt.tolerance); err != nil {
+				t.Errorf("SolveGameOf() = %v doesn't pass verification", got)
+			}
+
+			for i, p := range append(got.Probabilities1(), got.Probabilities2()...) {
+				if math.Abs(p-want[i]) > tt.tolerance {
+					t.Errorf("SolveGameOf() = (%v, %v), want %v", got.Probabilities1(), got.Probabilities2(), want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	m := matrix.Matrix{{0, -1, 1}, {1, 0, -1}, {-1, 1, 0}}
+
+	if err := NewSolution(m, matrix.Vector{1, 0, 0}, matrix.Vector{0, 1, 0}).Verify(m, 1e-9); err != ErrVerification {
+		t.Errorf("Verify() error = %v, want %v", err, ErrVerification)
+	}
+	if err := NewSolution(m, matrix.Vector{0.5, 0.5, 0.5}, matrix.Vector{0, 1, 0}).Verify(m, 1e-9); err != ErrVerification {
+		t.Errorf("Verify() error = %v, want %v", err, ErrVerification)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	tests := []struct {
+		name        string
+		m           matrix.Matrix
+		dominance   Dominance
+		mixed       bool
+		wantRows    []int
+		wantColumns []int
+	}{
+		{"weak", matrix.Matrix{
+			{1, 3, 4, 5},
+			{4, 4, 4, 6},
+			{5, 4, 3, 6},
+			{4, 3, 3, 2},
+			{5, 3, 4, 5},
+		}, DominanceWeak, false, []int{1}, []int{2}},
+		{"strict", matrix.Matrix{
+			{3, 1},
+			{2, 0},
+			{1, 2},
+		}, DominanceStrict, false, []int{0, 2}, []int{0, 1}},
+		{"mixed", matrix.Matrix{
+			{4, 0},
+			{0, 4},
+			{1, 1},
+		}, DominanceStrict, true, []int{0, 1}, []int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reduce(tt.m, tt.dominance, tt.mixed)
+			if !reflect.DeepEqual(got.Rows(), tt.wantRows) {
+				t.Errorf("Reduce() rows = %v, want %v", got.Rows(), tt.wantRows)
+			}
+			if !reflect.DeepEqual(got.Columns(), tt.wantColumns) {
+				t.Errorf("Reduce() columns = %v, want %v", got.Columns(), tt.wantColumns)
+			}
+		})
+	}
+}
+
+func TestReductionSteps(t *testing.T) {
+	m := matrix.Matrix{
+		{4, 0},
+		{0, 4},
+		{1, 1},
+	}
+
+	steps := Reduce(m, DominanceStrict, true).Steps()
+	if len(steps) != 1 {
+		t.Fatalf("Steps() = %v, want one step", steps)
+	}
+
+	step := steps[0]
+	if !step.IsRow() || step.Index() != 2 || step.Dominance() != DominanceStrict {
+		t.Errorf("Steps() = %v, want row 3 strictly dominated", step)
+	}
+	if !reflect.DeepEqual(step.By(), []int{0, 1}) || !reflect.DeepEqual(step.Weights(), matrix.Vector{0.5, 0.5}) {
+		t.Errorf("Steps() by = %v with %v, want [0 1] with [0.5 0.5]", step.By(), step.Weights())
+	}
+	if want := m[:2]; !reflect.DeepEqual(step.Matrix(), want) {
+		t.Errorf("Steps() matrix = %v, want %v", step.Matrix(), want)
+	}
+}
+
+func TestSolveGameReduced(t *testing.T) {
+	m := matrix.Matrix{
+		{4, 0, 5},
+		{0, 4, 5},
+		{1, 1, 0},
+	}
+
+	got, _, err := SolveGameReduced(m, DominanceStrict, true)
+	if err != nil {
+		t.Fatalf("SolveGameReduced() error = %v", err)
+	}
+	if want := (matrix.Vector{0.5, 0.5, 0}); !reflect.DeepEqual(got.Probabilities1(), want) {
+		t.Errorf("SolveGameReduced() ps1 = %v, want %v", got.Probabilities1(), want)
+	}
+	if want := (matrix.Vector{0.5, 0.5, 0}); !reflect.DeepEqual(got.Probabilities2(), want) {
+		t.Errorf("SolveGameReduced() ps2 = %v, want %v", got.Probabilities2(), want)
+	}
+	if got.Cost() != 2 {
+		t.Errorf("SolveGameReduced() cost = %v, want 2", got.Cost())
+	}
+}
+
+func TestSolveGame2x2(t *testing.T) {
+	m := matrix.Matrix{
+		{-6, 1},
+		{3, -7},
+	}
+
+	got := SolveGame2x2(m)
+	if want := (matrix.Vector{10.0 / 17, 7.0 / 17}); !reflect.DeepEqual(got.Probabilities1(), want) {
+		t.Errorf("SolveGame2x2() ps1 = %v, want %v", got.Probabilities1(), want)
+	}
+	if want := (matrix.Vector{8.0 / 17, 9.0 / 17}); !reflect.DeepEqual(got.Probabilities2(), want) {
+		t.Errorf("SolveGame2x2() ps2 = %v, want %v", got.Probabilities2(), want)
+	}
+	if want := -39.0 / 17; math.Abs(got.Cost()-want) > 1e-9 {
+		t.Errorf("SolveGame2x2() cost = %v, want %v", got.Cost(), want)
+	}
+}
+
+func TestSolveGameBrownRobinson(t *testing.T) {
+	m := matrix.Matrix{
+		{2, 3, 11},
+		{7, 5, 2},
+	}
+
+	steps := []BrownRobinsonStep{}
+	collect := func(step BrownRobinsonStep) {
+		steps = append(steps, step)
+	}
+
+	got, err := SolveGameBrownRobinson(m, 0, 0.05, collect)
+	if err != nil {
+		t.Fatalf("SolveGameBrownRobinson() error = %v", err)
+	}
+	if want := 49.0 / 11; math.Abs(got.Cost()-want) > 0.05 {
+		t.Errorf("SolveGameBrownRobinson() cost = %v, want %v", got.Cost(), want)
+	}
+	if last := steps[len(steps)-1]; last.K() != len(steps) || last.Upper()-last.Lower() < 0 {
+		t.Errorf("SolveGameBrownRobinson() last step = %v, want k = %d", last, len(steps))
+	}
+
+	// the table is optional, the solution is the same without it
+	withoutSteps, err := SolveGameBrownRobinson(m, 0, 0.05, nil)
+	if err != nil || withoutSteps.Cost() != got.Cost() {
+		t.Errorf("SolveGameBrownRobinson() without steps = %v, %v, want %v", withoutSteps.Cost(), err, got.Cost())
+	}
+
+	steps = steps[:0]
+	got, _ = SolveGameBrownRobinson(m, 10, 0, collect)
+	if len(steps) != 10 || math.Abs(got.Probabilities1().Sum()-1) > 1e-9 {
+		t.Errorf("SolveGameBrownRobinson() made %d steps, ps1 = %v", len(steps), got.Probabilities1())
+	}
+
+	if _, err := SolveGameBrownRobinson(m, 0, 0, nil); err != ErrNoStopCondition {
+		t.Errorf("SolveGameBrownRobinson() error = %v, want %v", err, ErrNoStopCondition)
+	}
+
+	// the gap of the fictitious play shrinks too slowly to reach such tolerance
+	count := 0
+	if _, err := SolveGameBrownRobinson(m, 0, 1e-12, func(BrownRobinsonStep) { count++ }); err != ErrNotConverged || count != maxBrownRobinsonIterations {
+		t.Errorf("SolveGameBrownRobinson() made %d steps, error = %v, want %v", count, err, ErrNotConverged)
+	}
+
+	if _, err := SolveGameBrownRobinson(matrix.Matrix{}, 10, 0, nil); err != ErrEmpty {
+		t.Errorf("SolveGameBrownRobinson() error = %v, want %v", err, ErrEmpty)
+	}
+
+	if got := BrownRobinsonTable(nil); strings.Count(got, "\n") != 1 || !strings.Contains(got, "upper") {
+		t.Errorf("BrownRobinsonTable(nil) = %q, want the header", got)
+	}
+}
+
+func TestBimatrix(t *testing.T) {
+	g, err := NewBimatrix(
+		matrix.Matrix{{3, 3}, {2, 5}, {0, 6}},
+		matrix.Matrix{{3, 2}, {2, 6}, {3, 1}},
+	)
+	if err != nil {
+		t.Fatalf("NewBimatrix() error = %v", err)
+	}
+
+	pure := g.PureEquilibria()
+	if len(pure) != 1 || pure[0].Probabilities1()[0] != 1 || pure[0].Probabilities2()[0] != 1 {
+		t.Errorf("PureEquilibria() = %v, want the first row and column", pure)
+	}
+
+	want := []Equilibrium{
+		{matrix.Vector{1, 0, 0}, matrix.Vector{1, 0}, 3, 3},
+		{matrix.Vector{4.0 / 5, 1.0 / 5, 0}, matrix.Vector{2.0 / 3, 1.0 / 3}, 3, 14.0 / 5},
+		{matrix.Vector{0, 1.0 / 3, 2.0 / 3}, matrix.Vector{1.0 / 3, 2.0 / 3}, 4, 8.0 / 3},
+	}
+
+	got := g.SupportEnumeration()
+	if len(got) != len(want) {
+		t.Fatalf("SupportEnumeration() = %v, want %v", got, want)
+	}
+	for _, e := range want {
+		if !containsEquilibrium(got, e) {
+			t.Errorf("SupportEnumeration() misses %v", e)
+		}
+	}
+
+	for label := 0; label < 5; label++ {
+		e, err := g.LemkeHowson(label)
+		if err != nil {
+			t.Fatalf("LemkeHowson(%d) error = %v", label, err)
+		}
+		if !containsEquilibrium(want, e) {
+			t.Errorf("LemkeHowson(%d) = %v, not an equilibrium", label, e)
+		}
+	}
+
+	if _, err := NewBimatrix(matrix.Matrix{{1, 2}}, matrix.Matrix{{1}}); err != ErrDimensionMismatch {
+		t.Errorf("NewBimatrix() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	if _, err := NewBimatrix(matrix.Matrix{}, matrix.Matrix{}); err != ErrEmpty {
+		t.Errorf("NewBimatrix() error = %v, want %v", err, ErrEmpty)
+	}
+	if _, err := NewBimatrix(matrix.Matrix{{1}}, matrix.Matrix{{}}); err != ErrEmpty {
+		t.Errorf("NewBimatrix() error = %v, want %v", err, ErrEmpty)
+	}
+}
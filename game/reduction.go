@@ -0,0 +1,339 @@
+package game
+
+import (
+	"fmt"
+	"gomo/matrix"
+)
+
+// Dominance shows which dominance is used to remove strategies
+type Dominance int
+
+const (
+	// DominanceStrict removes a strategy only if another one is better against every opponent's strategy
+	DominanceStrict Dominance = iota
+	// DominanceWeak removes a strategy if another one is not worse against every opponent's strategy
+	DominanceWeak Dominance = iota
+)
+
+func (d Dominance) String() string {
+	if d == DominanceStrict {
+		return "strictly"
+	}
+
+	return "weakly"
+}
+
+// ReductionStep describes one removed strategy
+type ReductionStep struct {
+	isRow bool
+	index int
+	// by holds dominating strategies with their weights, there are two of them for mixed dominance
+	by        []int
+	weights   matrix.Vector
+	dominance Dominance
+	matrix    matrix.Matrix
+}
+
+// Reduction contains a game matrix reduced by dominance and the way back to the original strategies
+type Reduction struct {
+	original matrix.Matrix
+	reduced  matrix.Matrix
+	// rows and columns hold original indexes of kept strategies
+	rows    []int
+	columns []int
+	steps   []ReductionStep
+}
+
+// Matrix returns the reduced matrix
+func (r Reduction) Matrix() matrix.Matrix {
+	return r.reduced
+}
+
+// Rows returns original indexes of kept rows
+func (r Reduction) Rows() []int {
+	return r.rows
+}
+
+// Columns returns original indexes of kept columns
+func (r Reduction) Columns() []int {
+	return r.columns
+}
+
+// Steps returns removed strategies in order of removal
+func (r Reduction) Steps() []ReductionStep {
+	return r.steps
+}
+
+// Reduce iteratively removes dominated rows and columns. If mixed is set,
+// strategies dominated by a mix of two other strategies are removed too
+func Reduce(m matrix.Matrix, dominance Dominance, mixed bool) Reduction {
+	w, h := m.Size()
+
+	r := Reduction{
+		original: m,
+		reduced:  m.Clone(),
+		rows:     make([]int, h),
+		columns:  make([]int, w),
+	}
+
+	for y := range r.rows {
+		r.rows[y] = y
+	}
+	for x := range r.columns {
+		r.columns[x] = x
+	}
+
+	for {
+		step, ok := r.findDominated(dominance, mixed)
+		if !ok {
+			return r
+		}
+
+		r = r.remove(step)
+	}
+}
+
+// IsRow checks if the removed strategy is a row, otherwise it's a column
+func (step ReductionStep) IsRow() bool {
+	return step.isRow
+}
+
+// Index returns the original index of the removed strategy
+func (step ReductionStep) Index() int {
+	return step.index
+}
+
+// By returns the original indexes of the dominating strategies, there are two of them for mixed dominance
+func (step ReductionStep) By() []int {
+	return step.by
+}
+
+// Weights returns the weights of the dominating strategies in the same order as By
+func (step ReductionStep) Weights() matrix.Vector {
+	return step.weights
+}
+
+// Dominance returns the dominance the strategy is removed by
+func (step ReductionStep) Dominance() Dominance {
+	return step.dominance
+}
+
+// Matrix returns the game matrix after the removal
+func (step ReductionStep) Matrix() matrix.Matrix {
+	return step.matrix
+}
+
+// findDominated looks for a dominated row and then for a dominated column
+func (r Reduction) findDominated(dominance Dominance, mixed bool) (ReductionStep, bool) {
+	if len(r.rows) > 1 {
+		// the row player maximises
+		if index, by, weights, ok := dominatedLine(r.reduced, dominance, mixed); ok {
+			return ReductionStep{true, index, by, weights, dominance, nil}, true
+		}
+	}
+
+	if len(r.columns) > 1 {
+		// the column player minimises so columns are compared with the opposite sign
+		if index, by, weights, ok := dominatedLine(r.reduced.Transpose().MultiplyWithNumber(-1), dominance, mixed); ok {
+			return ReductionStep{false, index, by, weights, dominance, nil}, true
+		}
+	}
+
+	return ReductionStep{}, false
+}
+
+// remove removes the strategy of the step (given in reduced indexes) and records the step with original indexes
+func (r Reduction) remove(step ReductionStep) Reduction {
+	kept := r.rows
+	if !step.isRow {
+		kept = r.columns
+	}
+
+	by := make([]int, len(step.by))
+	for i, index := range step.by {
+		by[i] = kept[index]
+	}
+
+	removedIndex := step.index
+	newKept := append(append([]int{}, kept[:removedIndex]...), kept[removedIndex+1:]...)
+
+	var reduced matrix.Matrix
+	if step.isRow {
+		reduced = append(append(matrix.Matrix{}, r.reduced[:removedIndex]...), r.reduced[removedIndex+1:]...)
+		r.rows = newKept
+	} else {
+		columns := r.reduced.Transpose()
+		columns = append(append(matrix.Matrix{}, columns[:removedIndex]...), columns[removedIndex+1:]...)
+		reduced = columns.Transpose()
+		r.columns = newKept
+	}
+
+	step.index = kept[removedIndex]
+	step.by = by
+	step.matrix = reduced.Clone()
+
+	r.reduced = reduced
+	r.steps = append(append([]ReductionStep{}, r.steps...), step)
+
+	return r
+}
+
+// dominates checks if line1 is better than line2 for the maximising player
+func dominates(line1, line2 matrix.Vector, dominance Dominance) bool {
+	for i, value := range line1 {
+		if dominance == DominanceStrict && value <= line2[i]+matrix.Epsilon {
+			return false
+		}
+		if dominance == DominanceWeak && value < line2[i]-matrix.Epsilon {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mixedWeight looks for lambda so that lambda * line1 + (1 - lambda) * line2 dominates the line
+func mixedWeight(line, line1, line2 matrix.Vector, dominance Dominance) (float64, bool) {
+	// every element gives a condition lambda * (a1 - a2) >= a - a2
+	low, high := 0.0, 1.0
+	for i, value := range line {
+		diff := line1[i] - line2[i]
+		right := value - line2[i]
+
+		switch {
+		case diff > matrix.Epsilon:
+			low = maxFloat(low, right/diff)
+		case diff < -matrix.Epsilon:
+			high = minFloat(high, right/diff)
+		}
+	}
+
+	if low > high+matrix.Epsilon {
+		return 0, false
+	}
+
+	lambda := (low + high) / 2
+	mix := line1.MultiplyWithNumber(lambda)
+	for i, value := range line2 {
+		mix[i] += (1 - lambda) * value
+	}
+
+	return lambda, dominates(mix, line, dominance)
+}
+
+// dominatedLine finds a row dominated by another row or (if mixed is set) by a mix of two rows
+func dominatedLine(m matrix.Matrix, dominance Dominance, mixed bool) (int, []int, matrix.Vector, bool) {
+	for y, row := range m {
+		for y1, row1 := range m {
+			if y1 != y && dominates(row1, row, dominance) {
+				return y, []int{y1}, matrix.Vector{1}, true
+			}
+		}
+	}
+
+	if !mixed {
+		return -1, nil, nil, false
+	}
+
+	for y, row := range m {
+		for y1 := range m {
+			for y2 := y1 + 1; y2 < len(m); y2++ {
+				if y1 == y || y2 == y {
+					continue
+				}
+
+				if lambda, ok := mixedWeight(row, m[y1], m[y2], dominance); ok {
+					return y, []int{y1, y2}, matrix.Vector{lambda, 1 - lambda}, true
+				}
+			}
+		}
+	}
+
+	return -1, nil, nil, false
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// Expand maps solution of the reduced game to the original strategies, removed strategies get zero probability
+func (r Reduction) Expand(s Solution) Solution {
+	w, h := r.original.Size()
+
+	probabilities1 := matrix.ShellV(h)
+	for i, y := range r.rows {
+		probabilities1[y] = s.probabilities1[i]
+	}
+
+	probabilities2 := matrix.ShellV(w)
+	for i, x := range r.columns {
+		probabilities2[x] = s.probabilities2[i]
+	}
+
+	return Solution{
+		probabilities1: probabilities1,
+		probabilities2: probabilities2,
+		cost:           s.cost,
+		bounds:         GetBounds(r.original),
+	}
+}
+
+// SolveGameReduced removes dominated strategies, solves the reduced game and expands the solution back
+func SolveGameReduced(m matrix.Matrix, dominance Dominance, mixed bool) (Solution, Reduction, error) {
+	r := Reduce(m, dominance, mixed)
+	reduced := r.Matrix()
+
+	if w, h := reduced.Size(); w == 2 && h == 2 {
+		return r.Expand(SolveGame2x2(reduced)), r, nil
+	}
+
+	s, err := SolveGame(reduced)
+	if err != nil {
+		return Solution{}, r, err
+	}
+
+	return r.Expand(s), r, nil
+}
+
+func (step ReductionStep) String() string {
+	kind := "column"
+	if step.isRow {
+		kind = "row"
+	}
+
+	str := fmt.Sprintf("%s %d is %s dominated by", kind, step.index+1, step.dominance)
+	for i, index := range step.by {
+		if len(step.by) > 1 {
+			str += fmt.Sprintf(" %.3f*", step.weights[i])
+		} else {
+			str += " "
+		}
+		str += fmt.Sprintf("%s %d", kind, index+1)
+		if i != len(step.by)-1 {
+			str += " +"
+		}
+	}
+
+	return str + "\n" + step.matrix.String()
+}
+
+func (r Reduction) String() string {
+	str := ""
+	for _, step := range r.steps {
+		str += step.String()
+	}
+
+	return str + fmt.Sprintf("rows:\t%v\ncolumns:\t%v", r.rows, r.columns)
+}
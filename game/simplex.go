@@ -0,0 +1,53 @@
+package game
+
+import (
+	"gomo/lpt"
+	"gomo/matrix"
+)
+
+// solveColumnPlayer finds the optimal strategy q of the second (minimizing) player and the value of the game.
+// The matrix is shifted to positive payoffs v' and y = q / v' solves the linear program
+// Z = y1 + ... + yn -> max, A' y <= 1, y >= 0, so v' = 1 / Z. The program is solved with the arithmetic of T
+func solveColumnPlayer[T matrix.Real[T]](m matrix.Matrix) (matrix.Vector, float64, error) {
+	w, h := m.Size()
+
+	min := m[0][0]
+	for _, row := range m {
+		for _, value := range row {
+			if value < min {
+				min = value
+			}
+		}
+	}
+	shift := 1 - min
+
+	// the rows are [A' | 1]
+	limitations := matrix.ShellM(w+1, h)
+	operators := make([]lpt.Operator, h)
+	for y, row := range m {
+		for x, value := range row {
+			limitations[y][x] = value + shift
+		}
+		limitations[y][w] = 1
+		operators[y] = lpt.OperatorLessOrEqual
+	}
+
+	y, z, err := lpt.SolveOf[T](lpt.LPT{}.
+		SetMatrix(limitations, operators).
+		SetSignConditionToEvery(lpt.OperatorGreaterOrEqual).
+		SetTargetCoeffs(matrix.ShellVWithValue(w, 1), lpt.BoundMax))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if z.Float64() < matrix.Epsilon {
+		return nil, 0, ErrNoSolution
+	}
+
+	q := matrix.ShellV(w)
+	for i, el := range y {
+		q[i] = el.Div(z).Float64()
+	}
+
+	return q, z.One().Div(z).Float64() - shift, nil
+}
@@ -0,0 +1,269 @@
+package graphical
+
+import (
+	"errors"
+	"fmt"
+	"gomo/game"
+	"gomo/matrix"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+)
+
+// ErrNotTwoStrategies is returned when none of the players has exactly two strategies
+var ErrNotTwoStrategies = errors.New("graphical: game must be 2xn or mx2")
+
+// Envelope is the lower or the upper envelope of the lines y = a + (b - a) * x, x in [0, 1].
+// Every row of Lines is [a, b]: line values at x = 0 and x = 1
+type Envelope struct {
+	Lines matrix.Matrix
+	Upper bool
+	// Points are the vertices of the envelope from x = 0 to x = 1
+	Points []Point
+}
+
+// GameSolution contains data about a game solved with the graphical method
+type GameSolution struct {
+	Solution game.Solution
+	Envelope Envelope
+	// Optimum is the optimal mixing point, X is probability of the first strategy of the player that mixes on the plot
+	Optimum Point
+	// Active holds indexes of the lines going through the optimum
+	Active []int
+	// LinesAreRows is set for mx2 games, where lines are rows and the second player mixes on the plot
+	LinesAreRows bool
+}
+
+// NewEnvelope calculates the envelope vertices
+func NewEnvelope(lines matrix.Matrix, upper bool) Envelope {
+	xs := []float64{0, 1}
+	for i, line1 := range lines {
+		for _, line2 := range lines[i+1:] {
+			// a1 + k1 * x = a2 + k2 * x
+			k1, k2 := line1[1]-line1[0], line2[1]-line2[0]
+			if math.Abs(k1-k2) < matrix.Epsilon {
+				continue
+			}
+
+			if x := (line2[0] - line1[0]) / (k1 - k2); x > matrix.Epsilon && x < 1-matrix.Epsilon {
+				xs = append(xs, x)
+			}
+		}
+	}
+
+	sort.Float64s(xs)
+
+	e := Envelope{Lines: lines, Upper: upper}
+	for _, x := range xs {
+		if len(e.Points) != 0 && x-e.Points[len(e.Points)-1].X < matrix.Epsilon {
+			continue
+		}
+
+		e.Points = append(e.Points, Point{x, e.ValueAt(x)})
+	}
+
+	return e
+}
+
+// lineValue returns value of the line at x
+func lineValue(line matrix.Vector, x float64) float64 {
+	return line[0] + (line[1]-line[0])*x
+}
+
+// ValueAt returns the envelope value at x
+func (e Envelope) ValueAt(x float64) float64 {
+	value := lineValue(e.Lines[0], x)
+	for _, line := range e.Lines[1:] {
+		if e.Upper {
+			value = math.Max(value, lineValue(line, x))
+		} else {
+			value = math.Min(value, lineValue(line, x))
+		}
+	}
+
+	return value
+}
+
+// Optimum returns the highest point of the lower envelope (the lowest point of the upper one)
+// and the lines going through it
+func (e Envelope) Optimum() (Point, []int) {
+	optimum := e.Points[0]
+	for _, p := range e.Points[1:] {
+		if (!e.Upper && p.Y > optimum.Y+matrix.Epsilon) || (e.Upper && p.Y < optimum.Y-matrix.Epsilon) {
+			optimum = p
+		}
+	}
+
+	active := []int{}
+	for i, line := range e.Lines {
+		if math.Abs(lineValue(line, optimum.X)-optimum.Y) < matrix.Epsilon {
+			active = append(active, i)
+		}
+	}
+
+	return optimum, active
+}
+
+// opponentLines chooses lines the opponent of the mixing player uses at the optimum:
+// one line if it's enough to hold the optimal value and two lines of opposite slopes otherwise
+func (e Envelope) opponentLines(optimum Point, active []int) []int {
+	slope := func(i int) float64 {
+		return e.Lines[i][1] - e.Lines[i][0]
+	}
+
+	minSlope, maxSlope := active[0], active[0]
+	for _, i := range active {
+		if math.Abs(slope(i)) < matrix.Epsilon {
+			return []int{i}
+		}
+
+		if slope(i) < slope(minSlope) {
+			minSlope = i
+		}
+		if slope(i) > slope(maxSlope) {
+			maxSlope = i
+		}
+	}
+
+	// at the left end the lower envelope must not go up and the upper one must not go down
+	if optimum.X < matrix.Epsilon {
+		if e.Upper {
+			return []int{maxSlope}
+		}
+		return []int{minSlope}
+	}
+
+	if optimum.X > 1-matrix.Epsilon {
+		if e.Upper {
+			return []int{minSlope}
+		}
+		return []int{maxSlope}
+	}
+
+	return []int{minSlope, maxSlope}
+}
+
+// SolveGame solves 2xn or mx2 game: finds the optimum of the envelope of the strategy lines
+// and solves the 2x2 game of the active strategies
+func SolveGame(m matrix.Matrix) (GameSolution, error) {
+	w, h := m.Size()
+
+	var lines matrix.Matrix
+	linesAreRows := false
+	switch {
+	case h == 2:
+		// lines are columns, x is probability of the first row
+		lines = matrix.Matrix{m[1], m[0]}.Transpose()
+	case w == 2:
+		// lines are rows, x is probability of the first column
+		linesAreRows = true
+		lines = matrix.Matrix{m.GetColumn(1), m.GetColumn(0)}.Transpose()
+	default:
+		return GameSolution{}, ErrNotTwoStrategies
+	}
+
+	envelope := NewEnvelope(lines, linesAreRows)
+	optimum, active := envelope.Optimum()
+	chosen := envelope.opponentLines(optimum, active)
+
+	mixing := matrix.Vector{optimum.X, 1 - optimum.X}
+	opponent := matrix.ShellV(len(lines))
+
+	if len(chosen) == 1 {
+		opponent[chosen[0]] = 1
+	} else {
+		var sub game.Solution
+		if linesAreRows {
+			sub = game.SolveGame2x2(matrix.Matrix{m[chosen[0]], m[chosen[1]]})
+			mixing = sub.Probabilities2()
+			opponent[chosen[0]] = sub.Probabilities1()[0]
+			opponent[chosen[1]] = sub.Probabilities1()[1]
+		} else {
+			sub = game.SolveGame2x2(matrix.Matrix{
+				{m[0][chosen[0]], m[0][chosen[1]]},
+				{m[1][chosen[0]], m[1][chosen[1]]},
+			})
+			mixing = sub.Probabilities1()
+			opponent[chosen[0]] = sub.Probabilities2()[0]
+			opponent[chosen[1]] = sub.Probabilities2()[1]
+		}
+	}
+
+	var solution game.Solution
+	if linesAreRows {
+		solution = game.NewSolution(m, opponent, mixing)
+	} else {
+		solution = game.NewSolution(m, mixing, opponent)
+	}
+
+	return GameSolution{
+		Solution:     solution,
+		Envelope:     envelope,
+		Optimum:      optimum,
+		Active:       active,
+		LinesAreRows: linesAreRows,
+	}, nil
+}
+
+// Plot draws the strategy lines, the envelope and the optimum
+func (s GameSolution) Plot() (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+
+	p.Title.Text = "Graphical method"
+	p.X.Min = 0
+	p.X.Max = 1
+
+	name := "B"
+	if s.LinesAreRows {
+		name = "A"
+		p.X.Label.Text = "q"
+	} else {
+		p.X.Label.Text = "p"
+	}
+	p.Y.Label.Text = "payoff"
+
+	for i, line := range s.Envelope.Lines {
+		points := []Point{{0, line[0]}, {1, line[1]}}
+		if err := addLine(p, fmt.Sprintf("%s%d", name, i+1), points, i, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := addLine(p, "envelope", s.Envelope.Points, len(s.Envelope.Lines), 3); err != nil {
+		return nil, err
+	}
+
+	if err := addPoint(p, "optimum", s.Optimum); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Save draws the plot to PNG or SVG file
+func (s GameSolution) Save(file string) error {
+	p, err := s.Plot()
+	if err != nil {
+		return err
+	}
+
+	return save(p, file)
+}
+
+func (s GameSolution) String() string {
+	str := "envelope:"
+	for _, p := range s.Envelope.Points {
+		str += fmt.Sprintf(" (%.3f, %.3f)", p.X, p.Y)
+	}
+
+	str += fmt.Sprintf("\noptimum:\t(%.3f, %.3f)\nactive:", s.Optimum.X, s.Optimum.Y)
+	for _, i := range s.Active {
+		str += fmt.Sprintf(" %d", i+1)
+	}
+
+	return str + "\n" + s.Solution.String()
+}
@@ -0,0 +1,104 @@
+package graphical
+
+import (
+	"gomo/matrix"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSolveGame(t *testing.T) {
+	tests := []struct {
+		name  string
+		m     matrix.Matrix
+		want1 matrix.Vector
+		want2 matrix.Vector
+		cost  float64
+	}{
+		{"2xn", matrix.Matrix{
+			{2, 3, 11},
+			{7, 5, 2},
+		}, matrix.Vector{3.0 / 11, 8.0 / 11}, matrix.Vector{0, 9.0 / 11, 2.0 / 11}, 49.0 / 11},
+		{"mx2", matrix.Matrix{
+			{2, 7},
+			{3, 5},
+			{11, 2},
+		}, matrix.Vector{9.0 / 14, 0, 5.0 / 14}, matrix.Vector{5.0 / 14, 9.0 / 14}, 73.0 / 14},
+		{"saddle", matrix.Matrix{
+			{1, 2, 3},
+			{0, 4, 5},
+		}, matrix.Vector{1, 0}, matrix.Vector{1, 0, 0}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SolveGame(tt.m)
+			if err != nil {
+				t.Fatalf("SolveGame() error = %v", err)
+			}
+
+			s := got.Solution
+			if !equal(s.Probabilities1(), tt.want1) || !equal(s.Probabilities2(), tt.want2) {
+				t.Errorf("SolveGame() = (%v, %v), want (%v, %v)", s.Probabilities1(), s.Probabilities2(), tt.want1, tt.want2)
+			}
+			if math.Abs(s.Cost()-tt.cost) > 1e-9 {
+				t.Errorf("SolveGame() cost = %v, want %v", s.Cost(), tt.cost)
+			}
+		})
+	}
+}
+
+func TestSolveGameSize(t *testing.T) {
+	if _, err := SolveGame(matrix.ShellM(3, 3)); err != ErrNotTwoStrategies {
+		t.Errorf("SolveGame() error = %v, want %v", err, ErrNotTwoStrategies)
+	}
+}
+
+func equal(v1, v2 matrix.Vector) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+
+	for i := range v1 {
+		if math.Abs(v1[i]-v2[i]) > 1e-9 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkSave saves the plot to PNG and SVG files and checks they are written
+func checkSave(t *testing.T, save func(file string) error) {
+	dir := t.TempDir()
+	for _, name := range []string{"plot.png", "plot.svg"} {
+		file := filepath.Join(dir, name)
+		if err := save(file); err != nil {
+			t.Fatalf("Save(%s) error = %v", name, err)
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatalf("Save(%s) didn't write the file: %v", name, err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("Save(%s) wrote empty file", name)
+		}
+	}
+
+	if err := save(filepath.Join(dir, "plot.pdf")); err != ErrUnsupportedFormat {
+		t.Errorf("Save(plot.pdf) error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestSaveGame(t *testing.T) {
+	got, err := SolveGame(matrix.Matrix{
+		{2, 3, 11},
+		{7, 5, 2},
+	})
+	if err != nil {
+		t.Fatalf("SolveGame() error = %v", err)
+	}
+
+	checkSave(t, got.Save)
+}
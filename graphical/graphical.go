@@ -1,41 +1,99 @@
 package graphical
 
 import (
+	"errors"
+	"image/color"
+	"path/filepath"
+
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
-	"gonum.org/v1/plot/vg/draw"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 )
 
-func main() {
-	p, err := plot.New()
+// ErrUnsupportedFormat is returned when a plot is saved to a file with extension other than .png or .svg
+var ErrUnsupportedFormat = errors.New("graphical: only .png and .svg files are supported")
+
+// Point is a point of a plot
+type Point struct {
+	X float64
+	Y float64
+}
+
+func toXYs(points []Point) plotter.XYs {
+	xys := make(plotter.XYs, len(points))
+	for i, p := range points {
+		xys[i].X = p.X
+		xys[i].Y = p.Y
+	}
+
+	return xys
+}
+
+// newLine makes a polyline with the color of provided index
+func newLine(points []Point, colorIndex int, width float64) (*plotter.Line, error) {
+	line, err := plotter.NewLine(toXYs(points))
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	line.Color = plotutil.Color(colorIndex)
+	line.Width = vg.Points(width)
+
+	return line, nil
+}
+
+// addLine adds a polyline to the plot with the color of provided index
+func addLine(p *plot.Plot, name string, points []Point, colorIndex int, width float64) error {
+	line, err := newLine(points, colorIndex, width)
+	if err != nil {
+		return err
+	}
+
+	p.Add(line)
+	if name != "" {
+		p.Legend.Add(name, line)
+	}
+
+	return nil
+}
+
+// addPolygon adds a filled polygon to the plot
+func addPolygon(p *plot.Plot, points []Point) error {
+	polygon, err := plotter.NewPolygon(toXYs(points))
+	if err != nil {
+		return err
+	}
+
+	polygon.Color = color.Gray{Y: 220}
+	p.Add(polygon)
+
+	return nil
+}
+
+// addPoint marks a point on the plot
+func addPoint(p *plot.Plot, name string, point Point) error {
+	scatter, err := plotter.NewScatter(toXYs([]Point{point}))
+	if err != nil {
+		return err
+	}
+
+	scatter.GlyphStyle.Shape = draw.CircleGlyph{}
+	scatter.GlyphStyle.Radius = vg.Points(4)
+
+	p.Add(scatter)
+	p.Legend.Add(name, scatter)
+
+	return nil
+}
+
+// save writes the plot to PNG or SVG file depending on the file extension
+func save(p *plot.Plot, file string) error {
+	switch filepath.Ext(file) {
+	case ".png", ".svg":
+		return p.Save(6*vg.Inch, 6*vg.Inch, file)
 	}
 
-	p.Title.Text = "Relabeling tick marks example"
-	p.X.Label.Text = "X"
-	p.Y.Label.Text = "Y"
-
-	plotutil.AddLinePoints(
-		p,
-		"First", plotter.XYs { 
-			{X: 5, Y: 15}, 
-			{X: 6, Y: 1}, 
-			{X: 7, Y: -5}, 
-			{X: 8, Y: 18}, 
-			{X: 10, Y: 2}, 
-		},
-		"Second", plotter.XYs { 
-			{X: 2, Y: 13}, 
-			{X: 3, Y: 13}, 
-			{X: 4, Y: -2}, 
-			{X: 5, Y: 18}, 
-			{X: 8, Y: 4}, 
-		},
-	)
-
-	draw.New(vg.CanvasSizer{})
-	p.Draw()
-}
\ No newline at end of file
+	return ErrUnsupportedFormat
+}
@@ -0,0 +1,434 @@
+package graphical
+
+import (
+	"errors"
+	"fmt"
+	"gomo/lpt"
+	"gomo/matrix"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+)
+
+// ErrNotTwoVariables is returned when the task has other than two variables
+var ErrNotTwoVariables = errors.New("graphical: task must have exactly two variables")
+
+// Status shows the result of solving a LPT
+type Status int
+
+const (
+	// StatusOptimal means the optimum is found
+	StatusOptimal Status = iota
+	// StatusInfeasible means the feasible region is empty
+	StatusInfeasible Status = iota
+	// StatusUnbounded means the target function is unbounded on the feasible region
+	StatusUnbounded Status = iota
+)
+
+func (status Status) String() string {
+	switch status {
+	case StatusOptimal:
+		return "optimal"
+	case StatusInfeasible:
+		return "infeasible"
+	case StatusUnbounded:
+		return "unbounded"
+	}
+
+	return "Undefined"
+}
+
+// HalfPlane is the condition A*x1 + B*x2 <= C
+type HalfPlane struct {
+	A float64
+	B float64
+	C float64
+}
+
+// LPSolution contains data about a two-variable LPT solved with the graphical method
+type LPSolution struct {
+	Status Status
+	// Lines are the limitations and sign conditions as rows [a1, a2, b]
+	Lines matrix.Matrix
+	// Polygon is the feasible region, unbounded region is cut by a big square
+	Polygon []Point
+	// Vertices are the real vertices of the feasible region
+	Vertices []Point
+	// RegionIsUnbounded is set when the feasible region is unbounded
+	RegionIsUnbounded bool
+	Optimum           Point
+	Value             float64
+	coeffs            matrix.Vector
+	bound             lpt.Bound
+}
+
+// halfPlanes maps conditions to half-planes, equality gives two of them
+func halfPlanes(m matrix.Matrix, operators []lpt.Operator) []HalfPlane {
+	planes := []HalfPlane{}
+	for i, row := range m {
+		plane := HalfPlane{row[0], row[1], row[len(row)-1]}
+		opposite := HalfPlane{-plane.A, -plane.B, -plane.C}
+
+		switch operators[i] {
+		case lpt.OperatorLess, lpt.OperatorLessOrEqual:
+			planes = append(planes, plane)
+		case lpt.OperatorGreater, lpt.OperatorGreaterOrEqual:
+			planes = append(planes, opposite)
+		case lpt.OperatorEqual:
+			planes = append(planes, plane, opposite)
+		}
+	}
+
+	return planes
+}
+
+func (h HalfPlane) value(p Point) float64 {
+	return h.A*p.X + h.B*p.Y - h.C
+}
+
+// clip cuts the convex polygon by the half-plane (Sutherland-Hodgman)
+func clip(polygon []Point, h HalfPlane) []Point {
+	tolerance := matrix.Epsilon * (1 + math.Abs(h.C) + math.Abs(h.A) + math.Abs(h.B))
+
+	result := []Point{}
+	for i, current := range polygon {
+		previous := polygon[(i+len(polygon)-1)%len(polygon)]
+		currentValue, previousValue := h.value(current), h.value(previous)
+
+		if (currentValue <= tolerance) != (previousValue <= tolerance) {
+			t := previousValue / (previousValue - currentValue)
+			result = appendPoint(result, Point{
+				previous.X + t*(current.X-previous.X),
+				previous.Y + t*(current.Y-previous.Y),
+			})
+		}
+
+		if currentValue <= tolerance {
+			result = appendPoint(result, current)
+		}
+	}
+
+	if len(result) > 1 && samePoint(result[0], result[len(result)-1]) {
+		result = result[:len(result)-1]
+	}
+
+	return result
+}
+
+func samePoint(p1, p2 Point) bool {
+	scale := 1 + math.Max(math.Abs(p1.X), math.Abs(p1.Y))
+	return math.Abs(p1.X-p2.X) < matrix.Epsilon*scale && math.Abs(p1.Y-p2.Y) < matrix.Epsilon*scale
+}
+
+func appendPoint(points []Point, p Point) []Point {
+	if len(points) != 0 && samePoint(points[len(points)-1], p) {
+		return points
+	}
+
+	return append(points, p)
+}
+
+// SolveLPT finds the feasible polygon of two-variable LPT and the optimum of the target function on it
+func SolveLPT(task lpt.LPT) (LPSolution, error) {
+	operators := task.Operators()
+	signs, signOperators := task.SignConditionsAsMatrix()
+	coeffs := task.TargetCoeffs()
+
+	if len(coeffs) != 2 || (len(signs) != 0 && signs.Width() > 3) {
+		return LPSolution{}, ErrNotTwoVariables
+	}
+
+	lines := matrix.Matrix{}
+	if len(operators) != 0 {
+		limitations := task.LimitationsAsMatrix()
+		if limitations.Width() != 3 {
+			return LPSolution{}, ErrNotTwoVariables
+		}
+
+		lines = limitations.Clone()
+	}
+
+	// the variables without sign conditions are free
+	for _, row := range signs {
+		lines = append(lines, matrix.Vector{row[0], row[1], 0})
+	}
+
+	planes := halfPlanes(lines, append(operators, signOperators...))
+
+	// a square much bigger than every intercept stands for the infinity
+	size := 1.0
+	for _, h := range planes {
+		if norm := math.Max(math.Abs(h.A), math.Abs(h.B)); norm > matrix.Epsilon {
+			size = math.Max(size, math.Abs(h.C)/norm)
+		}
+	}
+	size *= 1e6
+
+	polygon := []Point{{-size, -size}, {size, -size}, {size, size}, {-size, size}}
+	for _, h := range planes {
+		polygon = clip(polygon, h)
+	}
+
+	s := LPSolution{
+		Lines:   lines,
+		Polygon: polygon,
+		coeffs:  coeffs,
+		bound:   task.Bound(),
+	}
+
+	if len(polygon) == 0 {
+		s.Status = StatusInfeasible
+		return s, nil
+	}
+
+	isReal := func(p Point) bool {
+		return math.Abs(p.X) < size*(1-matrix.Epsilon) && math.Abs(p.Y) < size*(1-matrix.Epsilon)
+	}
+
+	for _, p := range polygon {
+		if isReal(p) {
+			s.Vertices = append(s.Vertices, p)
+		} else {
+			s.RegionIsUnbounded = true
+		}
+	}
+
+	// the vertices of the big square are calculated with the errors relative to its size,
+	// the real ones relative to their own coordinates
+	valueTolerance := func(p Point) float64 {
+		if !isReal(p) {
+			return matrix.Epsilon * size * (1 + math.Abs(coeffs[0]) + math.Abs(coeffs[1]))
+		}
+
+		return matrix.Epsilon * (1 + math.Abs(coeffs[0]*p.X) + math.Abs(coeffs[1]*p.Y))
+	}
+	tolerance := func(p, q Point) float64 {
+		return math.Max(valueTolerance(p), valueTolerance(q))
+	}
+
+	best := -1
+	for i, p := range polygon {
+		value := s.objective(p)
+		if best == -1 {
+			best = i
+			continue
+		}
+
+		bestValue := s.objective(polygon[best])
+		tolerance := tolerance(p, polygon[best])
+		isBetter := (s.bound == lpt.BoundMax && value > bestValue+tolerance) ||
+			(s.bound == lpt.BoundMin && value < bestValue-tolerance)
+		isSame := math.Abs(value-bestValue) <= tolerance
+
+		// real vertices are preferred to the ones of the big square
+		if isBetter || (isSame && isReal(p) && !isReal(polygon[best])) {
+			best = i
+		}
+	}
+
+	if s.improvesAlongRecession(planes) {
+		s.Status = StatusUnbounded
+		s.Optimum = polygon[best]
+		s.Value = s.objective(s.Optimum)
+		return s, nil
+	}
+
+	// the bounded optimum without the real vertex is the edge of the big square, like the side of a strip,
+	// its point closest to the origin is taken
+	s.Optimum = polygon[best]
+	if !isReal(s.Optimum) {
+		bestValue := s.objective(polygon[best])
+		isOptimal := func(p Point) bool {
+			return math.Abs(s.objective(p)-bestValue) <= tolerance(p, polygon[best])
+		}
+
+		for i, p := range polygon {
+			next := polygon[(i+1)%len(polygon)]
+			if !isOptimal(p) || !isOptimal(next) {
+				continue
+			}
+
+			if closest := closestToOrigin(p, next); math.Hypot(closest.X, closest.Y) < math.Hypot(s.Optimum.X, s.Optimum.Y) {
+				s.Optimum = closest
+			}
+		}
+	}
+	s.Value = s.objective(s.Optimum)
+
+	return s, nil
+}
+
+// improvesAlongRecession checks if the target function improves along some direction d the region is unbounded in,
+// such directions satisfy A*d1 + B*d2 <= 0 for every half-plane. The best direction of the cone is its edge
+// lying on one of the lines or the gradient itself
+func (s LPSolution) improvesAlongRecession(planes []HalfPlane) bool {
+	sign := 1.0
+	if s.bound == lpt.BoundMin {
+		sign = -1
+	}
+
+	gradient := Point{sign * s.coeffs[0], sign * s.coeffs[1]}
+	norm := math.Hypot(gradient.X, gradient.Y)
+	if norm <= matrix.Epsilon {
+		return false
+	}
+
+	directions := []Point{{gradient.X / norm, gradient.Y / norm}}
+	for _, h := range planes {
+		if length := math.Hypot(h.A, h.B); length > matrix.Epsilon {
+			directions = append(directions, Point{-h.B / length, h.A / length}, Point{h.B / length, -h.A / length})
+		}
+	}
+
+	for _, d := range directions {
+		isRecession := true
+		for _, h := range planes {
+			if h.A*d.X+h.B*d.Y > matrix.Epsilon*(math.Abs(h.A)+math.Abs(h.B)) {
+				isRecession = false
+				break
+			}
+		}
+
+		if isRecession && gradient.X*d.X+gradient.Y*d.Y > matrix.Epsilon*norm {
+			return true
+		}
+	}
+
+	return false
+}
+
+// closestToOrigin returns the point of the segment closest to the origin
+func closestToOrigin(p1, p2 Point) Point {
+	dx, dy := p2.X-p1.X, p2.Y-p1.Y
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return p1
+	}
+
+	t := math.Max(0, math.Min(1, -(p1.X*dx+p1.Y*dy)/length))
+	return Point{p1.X + t*dx, p1.Y + t*dy}
+}
+
+func (s LPSolution) objective(p Point) float64 {
+	return s.coeffs[0]*p.X + s.coeffs[1]*p.Y
+}
+
+// window returns plot borders containing every real vertex and the origin
+func (s LPSolution) window() (Point, Point) {
+	min, max := Point{0, 0}, Point{0, 0}
+	for _, p := range s.Vertices {
+		min = Point{math.Min(min.X, p.X), math.Min(min.Y, p.Y)}
+		max = Point{math.Max(max.X, p.X), math.Max(max.Y, p.Y)}
+	}
+
+	margin := math.Max(1, 0.25*math.Max(max.X-min.X, max.Y-min.Y))
+	if s.RegionIsUnbounded {
+		margin *= 2
+	}
+
+	return Point{min.X - margin, min.Y - margin}, Point{max.X + margin, max.Y + margin}
+}
+
+// segment returns the part of the line a1*x1 + a2*x2 = b inside the window
+func segment(a1, a2, b float64, min, max Point) []Point {
+	window := []Point{min, {max.X, min.Y}, max, {min.X, max.Y}}
+
+	// the line is the window cut by two opposite half-planes
+	points := clip(clip(window, HalfPlane{a1, a2, b}), HalfPlane{-a1, -a2, -b})
+	if len(points) < 2 {
+		return nil
+	}
+
+	return []Point{points[0], points[len(points)-1]}
+}
+
+// Plot draws the limitation lines, the feasible region, level lines of the target function and the optimum
+func (s LPSolution) Plot() (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+
+	min, max := s.window()
+
+	p.Title.Text = "Graphical method"
+	p.X.Label.Text = "x1"
+	p.Y.Label.Text = "x2"
+	p.X.Min, p.X.Max = min.X, max.X
+	p.Y.Min, p.Y.Max = min.Y, max.Y
+
+	for i, line := range s.Lines {
+		if points := segment(line[0], line[1], line[2], min, max); points != nil {
+			if err := addLine(p, fmt.Sprintf("(%d)", i+1), points, i, 1); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.Status == StatusInfeasible {
+		return p, nil
+	}
+
+	region := s.Polygon
+	for _, h := range []HalfPlane{{1, 0, max.X}, {-1, 0, -min.X}, {0, 1, max.Y}, {0, -1, -min.Y}} {
+		region = clip(region, h)
+	}
+
+	if len(region) > 2 {
+		if err := addPolygon(p, region); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, vertex := range s.Vertices {
+		points := segment(s.coeffs[0], s.coeffs[1], s.objective(vertex), min, max)
+		if points == nil {
+			continue
+		}
+
+		line, err := newLine(points, len(s.Lines), 1)
+		if err != nil {
+			return nil, err
+		}
+
+		line.Dashes = []vg.Length{vg.Points(4), vg.Points(4)}
+		p.Add(line)
+	}
+
+	if s.Status == StatusOptimal {
+		if err := addPoint(p, fmt.Sprintf("optimum: %.3f", s.Value), s.Optimum); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Save draws the plot to PNG or SVG file
+func (s LPSolution) Save(file string) error {
+	p, err := s.Plot()
+	if err != nil {
+		return err
+	}
+
+	return save(p, file)
+}
+
+func (s LPSolution) String() string {
+	str := "vertices:"
+	for _, p := range s.Vertices {
+		str += fmt.Sprintf(" (%.3f, %.3f)", p.X, p.Y)
+	}
+
+	if s.RegionIsUnbounded {
+		str += "\nregion is unbounded"
+	}
+
+	str += "\nstatus:\t" + s.Status.String()
+	if s.Status == StatusOptimal {
+		str += fmt.Sprintf("\noptimum:\t(%.3f, %.3f)\nZ:\t%f", s.Optimum.X, s.Optimum.Y, s.Value)
+	}
+
+	return str
+}
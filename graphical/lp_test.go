@@ -0,0 +1,124 @@
+package graphical
+
+import (
+	"gomo/lpt"
+	"gomo/matrix"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSolveLPT(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantStatus  Status
+		wantOptimum Point
+		wantValue   float64
+	}{
+		{"max", `
+| 1x1 +2x2 <= 8
+| 3x1 +1x2 <= 9
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, StatusOptimal, Point{2, 3}, 5},
+		{"min", `
+| 1x1 +1x2 >= 4
+| 1x1 -1x2 <= 2
+1x1 >= 0, 1x2 >= 0
+Z = 2x1 +3x2 -> (min)`, StatusOptimal, Point{3, 1}, 9},
+		// the far line makes the big square huge, but the real vertices differ by 1
+		{"far line", `
+| 1x1 +1x2 <= 1000000
+| 1x1 +0x2 <= 1
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +2x2 -> (max)`, StatusOptimal, Point{0, 1000000}, 2000000},
+		{"unbounded", `
+| 1x1 -1x2 <= 2
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, StatusUnbounded, Point{}, 0},
+		{"infeasible", `
+| 1x1 +1x2 <= 1
+| 1x1 +1x2 >= 3
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, StatusInfeasible, Point{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := lpt.ParseLPT(strings.Split(tt.input, "\n")[1:])
+
+			got, err := SolveLPT(task)
+			if err != nil {
+				t.Fatalf("SolveLPT() error = %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Fatalf("SolveLPT() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if got.Status != StatusOptimal {
+				return
+			}
+
+			if math.Abs(got.Optimum.X-tt.wantOptimum.X) > 1e-6 || math.Abs(got.Optimum.Y-tt.wantOptimum.Y) > 1e-6 {
+				t.Errorf("SolveLPT() optimum = %v, want %v", got.Optimum, tt.wantOptimum)
+			}
+			if math.Abs(got.Value-tt.wantValue) > 1e-6 {
+				t.Errorf("SolveLPT() value = %v, want %v", got.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestSolveLPTWithoutConditions(t *testing.T) {
+	tests := []struct {
+		name        string
+		task        lpt.LPT
+		wantOptimum Point
+		wantValue   float64
+	}{
+		// both variables are free, so the optimum is below zero
+		{"no sign conditions", lpt.LPT{}.
+			SetMatrix(matrix.Matrix{{-1, 1, -1}, {1, 1, -3}}, []lpt.Operator{lpt.OperatorGreaterOrEqual, lpt.OperatorGreaterOrEqual}).
+			SetTargetCoeffs(matrix.Vector{0, 1}, lpt.BoundMin), Point{-1, -2}, -2},
+		{"no limitations", lpt.LPT{}.
+			SetTargetCoeffs(matrix.Vector{1, 1}, lpt.BoundMin).
+			SetSignConditionToEvery(lpt.OperatorGreaterOrEqual), Point{0, 0}, 0},
+		// the strip 0 <= x1 <= 1 has no vertex, but x1 is bounded on it
+		{"strip", lpt.LPT{}.
+			SetMatrix(matrix.Matrix{{1, 0, 1}, {1, 0, 0}}, []lpt.Operator{lpt.OperatorLessOrEqual, lpt.OperatorGreaterOrEqual}).
+			SetTargetCoeffs(matrix.Vector{1, 0}, lpt.BoundMax), Point{1, 0}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SolveLPT(tt.task)
+			if err != nil {
+				t.Fatalf("SolveLPT() error = %v", err)
+			}
+			if got.Status != StatusOptimal {
+				t.Fatalf("SolveLPT() status = %v, want %v", got.Status, StatusOptimal)
+			}
+			if math.Abs(got.Optimum.X-tt.wantOptimum.X) > 1e-6 || math.Abs(got.Optimum.Y-tt.wantOptimum.Y) > 1e-6 {
+				t.Errorf("SolveLPT() optimum = %v, want %v", got.Optimum, tt.wantOptimum)
+			}
+			if math.Abs(got.Value-tt.wantValue) > 1e-6 {
+				t.Errorf("SolveLPT() value = %v, want %v", got.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestSaveLPT(t *testing.T) {
+	for _, input := range []string{`
+| 1x1 +2x2 <= 8
+| 3x1 +1x2 <= 9
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, `
+| 1x1 -1x2 <= 2
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`} {
+		got, err := SolveLPT(lpt.ParseLPT(strings.Split(input, "\n")[1:]))
+		if err != nil {
+			t.Fatalf("SolveLPT() error = %v", err)
+		}
+
+		checkSave(t, got.Save)
+	}
+}
@@ -3,7 +3,6 @@ package lpt
 import (
 	"fmt"
 	"gomo/matrix"
-	"math"
 	"strconv"
 	"strings"
 )
@@ -455,6 +454,45 @@ func (task LPT) LimitationsAsMatrix() matrix.Matrix {
 	return m
 }
 
+// Operators returns operators of tasks' limitations
+func (task LPT) Operators() []Operator {
+	operators := make([]Operator, len(task.limitations))
+	for i, lim := range task.limitations {
+		operators[i] = lim.operator
+	}
+
+	return operators
+}
+
+// SignConditionsAsMatrix returns tasks' sign conditions in Matrix form (b is 0) with their operators
+func (task LPT) SignConditionsAsMatrix() (matrix.Matrix, []Operator) {
+	w := len(task.targetFunction.coeffs)
+	for _, cond := range task.signConditions {
+		if len(cond.operandsLeft) > w {
+			w = len(cond.operandsLeft)
+		}
+	}
+
+	m := matrix.ShellM(w+1, len(task.signConditions))
+	operators := make([]Operator, len(task.signConditions))
+	for i, cond := range task.signConditions {
+		copy(m[i], cond.operandsLeft)
+		operators[i] = cond.operator
+	}
+
+	return m, operators
+}
+
+// TargetCoeffs returns coefficients of the target function
+func (task LPT) TargetCoeffs() matrix.Vector {
+	return task.targetFunction.coeffs
+}
+
+// Bound returns bound of the target function
+func (task LPT) Bound() Bound {
+	return task.targetFunction.bound
+}
+
 // LimitationsAsMatrix returns tasks' limitations in Matrix form
 func (task CLPT) LimitationsAsMatrix() matrix.Matrix {
 	m := matrix.ShellM(len(task.limitations[0].operandsLeft)+1, len(task.limitations))
@@ -472,8 +510,15 @@ func (task CLPT) LimitationsAsMatrix() matrix.Matrix {
 	return m
 }
 
+// SetSignConditionToEvery sets the sign condition to every variable, the variables are counted
+// by the target function when there are no limitations
 func (task LPT) SetSignConditionToEvery(operator Operator) LPT {
-	signConditions := make([]ConditionZero, len(task.limitations[0].operandsLeft))
+	variables := len(task.targetFunction.coeffs)
+	if len(task.limitations) != 0 {
+		variables = len(task.limitations[0].operandsLeft)
+	}
+
+	signConditions := make([]ConditionZero, variables)
 	for i := range signConditions {
 		signConditions[i] = ConditionZero{
 			matrix.ShellV(len(signConditions)).SetValue(i, 1),
@@ -496,6 +541,14 @@ func (task LPT) SetTargetFunction(targetFunction TargetFunction) LPT {
 	}
 }
 
+// SetTargetCoeffs sets target function like Z = c1x1 + c2x2 + ... -> bound
+func (task LPT) SetTargetCoeffs(coeffs matrix.Vector, bound Bound) LPT {
+	return task.SetTargetFunction(TargetFunction{
+		coeffs: coeffs.Clone(),
+		bound:  bound,
+	})
+}
+
 // SetMatrix sets limitations for a LPT
 func (task LPT) SetMatrix(m matrix.Matrix, operators []Operator) LPT {
 	limitations := make([]Condition, len(m))
@@ -535,7 +588,8 @@ func (task CLPT) SetMatrix(m matrix.Matrix) CLPT {
 	}
 }
 
-// DoSimplex performs Simplex transformation
+// DoSimplex performs Simplex transformation and prints every iteration. The pivots are chosen
+// by Bland's rule with matrix.PivotRow as LPT.Solve does, so both of them go the same way
 func (task CLPT) DoSimplex() (CLPT, matrix.Vector) {
 	println("\nAnother one Simplex iteration")
 
@@ -543,6 +597,8 @@ func (task CLPT) DoSimplex() (CLPT, matrix.Vector) {
 	w, h := m.Size()
 
 	baseVector := make(matrix.Vector, h)
+	basisNames := make([]string, h)
+	basis := make([]int, h)
 
 	columns := m.Transpose()
 	for x, column := range columns {
@@ -550,19 +606,43 @@ func (task CLPT) DoSimplex() (CLPT, matrix.Vector) {
 		onesCount := 0
 		onePosition := -1
 		for y, el := range column {
-			if el == 0.0 {
+			if matrix.IsZero(el) {
 				zerosCount++
-			} else if el == 1.0 {
+			} else if matrix.Equal(el, 1) {
 				onesCount++
 				onePosition = y
 			}
 		}
 
-		isBase := onesCount == 1 && zerosCount == h-1
-		if isBase {
-			baseVector[onePosition] = task.targetFunction.coeffs[x]
+		isBase := onesCount == 1 && zerosCount == h-1 && x < w-1
+		if !isBase {
+			continue
+		}
+
+		// the same base column may repeat, the one with the best coeff stays in the basis,
+		// so the others don't improve the target function and never enter it again
+		coeff := task.targetFunction.coeffs[x]
+		if basisNames[onePosition] != "" {
+			current := baseVector[onePosition]
+			if (task.targetFunction.bound == BoundMin && coeff >= current) ||
+				(task.targetFunction.bound == BoundMax && coeff <= current) {
+				continue
+			}
 		}
+
+		baseVector[onePosition] = coeff
+		basisNames[onePosition] = fmt.Sprintf("x%d", x+1)
+		basis[onePosition] = x
+	}
+
+	// the trace tables are labeled with the basis and the variables names
+	formatter := matrix.DefaultFormatter
+	formatter.ColumnLabels = make([]string, w)
+	for x := range formatter.ColumnLabels {
+		formatter.ColumnLabels[x] = fmt.Sprintf("x%d", x+1)
 	}
+	formatter.ColumnLabels[w-1] = "b"
+	formatter.RowLabels = basisNames
 
 	calcZ := func(i int) float64 {
 		product := columns[i].MultiplyElementByElement(baseVector).Sum()
@@ -570,6 +650,15 @@ func (task CLPT) DoSimplex() (CLPT, matrix.Vector) {
 		return product - coeff
 	}
 
+	// z-coeff of the column improving the target function is positive for min and negative for max
+	tolerance := m.Tolerance()
+	improves := func(z float64) bool {
+		if task.targetFunction.bound == BoundMin {
+			return z > tolerance
+		}
+		return z < -tolerance
+	}
+
 	B := m.GetLastColumn()
 
 	zValues := matrix.ShellV(len(columns))
@@ -577,58 +666,32 @@ func (task CLPT) DoSimplex() (CLPT, matrix.Vector) {
 	supportValueX := -1
 	supportValueY := -1
 
-	var supportValue float64
-
-	if task.targetFunction.bound == BoundMin {
-		supportValue = math.MaxFloat64
-		for x, column := range columns {
-			z := calcZ(x)
-			zValues[x] = z
-
-			if z > 0 && x < w-1 {
-				for y, el := range column {
-					if el > 0 {
-						c := B[y] / el
-						zCoeffs[y][x] = c
-
-						if c < supportValue {
-							supportValueX = x
-							supportValueY = y
-							supportValue = c
-						}
-					}
-				}
-			}
+	for x, column := range columns {
+		z := calcZ(x)
+		zValues[x] = z
+
+		if !improves(z) || x == w-1 {
+			continue
 		}
-	} else {
-		supportValue = -math.MaxFloat64
-		for x, column := range columns {
-			z := calcZ(x)
-			zValues[x] = z
-
-			if z < 0 && x < w-1 {
-				for y, el := range column {
-					if el > 0 {
-						c := B[y] / el
-						zCoeffs[y][x] = c
-
-						if c > supportValue {
-							supportValueX = x
-							supportValueY = y
-							supportValue = c
-						}
-					}
-				}
+
+		for y, el := range column {
+			if el > tolerance {
+				zCoeffs[y][x] = B[y] / el
 			}
 		}
-	}
 
-	// TODO: select row by the biggest value (zValues[x] * min(column of zCoeffs[x]))
+		// the first improving column with a positive element enters
+		if y := m.PivotRow(x, basis, tolerance); supportValueX == -1 && y != -1 {
+			supportValueX = x
+			supportValueY = y
+		}
+	}
 
 	println("Matrix of b_i / a_ik")
-	println(zCoeffs.String())
+	println(formatter.FormatMatrix(zCoeffs))
 	println("Vector of z-coeffs")
-	println(zValues.String())
+	formatter.RowLabels = []string{"z"}
+	println(formatter.FormatVector(zValues))
 
 	if supportValueX == -1 {
 		return task, zValues
@@ -770,8 +833,29 @@ func (task CLPT) String() string {
 	return task.ToLPT().String()
 }
 
-// String stringifies LPT
+// String stringifies LPT with matrix.DefaultFormatter, the values close to integers are humanized
 func (task LPT) String() string {
+	formatter := matrix.DefaultFormatter
+	formatter.Humanize = true
+	return task.Format(formatter)
+}
+
+// variableName returns the column label of the formatter or x1, x2, ... names
+func variableName(formatter matrix.Formatter, index int) string {
+	if index < len(formatter.ColumnLabels) {
+		return formatter.ColumnLabels[index]
+	}
+
+	return fmt.Sprintf("x%d", index+1)
+}
+
+// Format stringifies LPT with the formatter, the column labels are the names of the variables.
+// Plain style writes the task as the system, the other styles make the table of the coefficients
+func (task LPT) Format(formatter matrix.Formatter) string {
+	if formatter.Style != matrix.StylePlain {
+		return task.formatTable(formatter)
+	}
+
 	str := ""
 	for _, lim := range task.limitations {
 		str += "| "
@@ -783,7 +867,7 @@ func (task LPT) String() string {
 					sign = "+"
 				}
 
-				str += fmt.Sprintf("%s%sx%d ", sign, matrix.HumaniazeValue(value), x+1)
+				str += fmt.Sprintf("%s%s%s ", sign, formatter.FormatValue(value), variableName(formatter, x))
 
 				printedCounter++
 			}
@@ -791,7 +875,7 @@ func (task LPT) String() string {
 		}
 
 		str += lim.operator.String() + " "
-		str += matrix.HumaniazeValue(lim.operandRight)
+		str += formatter.FormatValue(lim.operandRight)
 		str += "\n"
 	}
 
@@ -804,7 +888,7 @@ func (task LPT) String() string {
 			}
 		}
 
-		str += fmt.Sprintf("1x%d %s 0", xIndex+1, condition.operator.String())
+		str += fmt.Sprintf("1%s %s 0", variableName(formatter, xIndex), condition.operator.String())
 		if i != len(task.signConditions)-1 {
 			str += ", "
 		}
@@ -820,7 +904,7 @@ func (task LPT) String() string {
 				sign = "+"
 			}
 
-			str += fmt.Sprintf("%s%sx%d ", sign, matrix.HumaniazeValue(value), i+1)
+			str += fmt.Sprintf("%s%s%s ", sign, formatter.FormatValue(value), variableName(formatter, i))
 		}
 	}
 
@@ -830,6 +914,47 @@ func (task LPT) String() string {
 	return str
 }
 
+// formatTable makes the table of the limitations and the target function,
+// the rows are labeled with the limitation numbers and Z
+func (task LPT) formatTable(formatter matrix.Formatter) string {
+	n := len(task.targetFunction.coeffs)
+
+	labels := make([]string, n+2)
+	rowLabels := []string{}
+	cells := [][]string{}
+
+	for x := 0; x < n; x++ {
+		labels[x] = variableName(formatter, x)
+	}
+	labels[n], labels[n+1] = "", "b"
+
+	for y, lim := range task.limitations {
+		row := make([]string, n+2)
+		for x, value := range lim.operandsLeft {
+			if x < n {
+				row[x] = formatter.FormatValue(value)
+			}
+		}
+		row[n] = lim.operator.String()
+		row[n+1] = formatter.FormatValue(lim.operandRight)
+
+		cells = append(cells, row)
+		rowLabels = append(rowLabels, fmt.Sprintf("%d", y+1))
+	}
+
+	row := make([]string, n+2)
+	for x, value := range task.targetFunction.coeffs {
+		row[x] = formatter.FormatValue(value)
+	}
+	row[n], row[n+1] = "->", task.targetFunction.bound.String()
+	cells = append(cells, row)
+	rowLabels = append(rowLabels, "Z")
+
+	formatter.ColumnLabels = labels
+	formatter.RowLabels = rowLabels
+	return formatter.FormatTable(cells)
+}
+
 // SetDefaultTargetFunction sets target function like Z = x1 + x2 + x3 + ... -> (max)
 func (task LPT) SetDefaultTargetFunction() LPT {
 	targetFunction := TargetFunction{
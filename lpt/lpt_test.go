@@ -0,0 +1,117 @@
+package lpt
+
+import (
+	"gomo/matrix"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		task      LPT
+		wantX     matrix.Vector
+		wantValue float64
+		wantErr   error
+	}{
+		{"max", ParseLPT(strings.Split(`| 1x1 +2x2 <= 8
+| 3x1 +1x2 <= 9
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, "\n")), matrix.Vector{2, 3}, 5, nil},
+		{"min", ParseLPT(strings.Split(`| 1x1 +1x2 >= 4
+| 1x1 -1x2 <= 2
+1x1 >= 0, 1x2 >= 0
+Z = 2x1 +3x2 -> (min)`, "\n")), matrix.Vector{3, 1}, 9, nil},
+		{"free variables", LPT{}.
+			SetMatrix(matrix.Matrix{{-1, 1, -1}, {1, 1, -3}}, []Operator{OperatorGreaterOrEqual, OperatorGreaterOrEqual}).
+			SetTargetCoeffs(matrix.Vector{0, 1}, BoundMin), matrix.Vector{-1, -2}, -2, nil},
+		{"degenerate", ParseLPT(strings.Split(`| 1x1 +1x2 <= 1
+| 1x1 +0x2 <= 1
+| 0x1 +1x2 <= 1
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, "\n")), nil, 1, nil},
+		{"infeasible", ParseLPT(strings.Split(`| 1x1 +1x2 <= 1
+| 1x1 +1x2 >= 3
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, "\n")), nil, 0, ErrInfeasible},
+		{"unbounded", ParseLPT(strings.Split(`| 1x1 -1x2 <= 2
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, "\n")), nil, 0, ErrUnbounded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, value, err := tt.task.Solve()
+			if err != tt.wantErr {
+				t.Fatalf("Solve() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if math.Abs(value-tt.wantValue) > 1e-9 {
+				t.Errorf("Solve() value = %v, want %v", value, tt.wantValue)
+			}
+			for i := range tt.wantX {
+				if math.Abs(x[i]-tt.wantX[i]) > 1e-9 {
+					t.Errorf("Solve() x = %v, want %v", x, tt.wantX)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSolveOf(t *testing.T) {
+	// the optimum of the degenerate task is the vertex of 3 lines, the exact ratios are equal
+	task := ParseLPT(strings.Split(`| 3x1 +1x2 <= 3
+| 1x1 +3x2 <= 3
+| 1x1 +1x2 <= 1.5
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, "\n"))
+
+	x, value, err := SolveOf[matrix.Rational](task)
+	if err != nil {
+		t.Fatalf("SolveOf() error = %v", err)
+	}
+	if got := x.String() + "= " + value.String(); got != "3/4 3/4 = 3/2" {
+		t.Errorf("SolveOf() = %q, want %q", got, "3/4 3/4 = 3/2")
+	}
+
+	_, single, err := SolveOf[matrix.Float32](task)
+	if err != nil || math.Abs(float64(single)-1.5) > 1e-6 {
+		t.Errorf("SolveOf() = %v, %v, want 1.5", single, err)
+	}
+}
+
+// TestDoSimplex checks that the trace of the method comes to the optimum LPT.Solve finds
+func TestDoSimplex(t *testing.T) {
+	tests := []struct {
+		name string
+		task LPT
+	}{
+		{"max", ParseLPT(strings.Split(`| 1x1 +2x2 <= 8
+| 3x1 +1x2 <= 9
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, "\n"))},
+		{"degenerate", ParseLPT(strings.Split(`| 1x1 +1x2 <= 1
+| 1x1 +0x2 <= 1
+| 0x1 +1x2 <= 1
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`, "\n"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, want, err := tt.task.Solve()
+			if err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+
+			// the z-coeff of b is the value of the target function
+			_, z := tt.task.CanonicalForm().DoSimplex()
+			if got := z[len(z)-1]; math.Abs(got-want) > 1e-9 {
+				t.Errorf("DoSimplex() value = %v, Solve() = %v", got, want)
+			}
+		})
+	}
+}
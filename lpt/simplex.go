@@ -0,0 +1,252 @@
+package lpt
+
+import (
+	"errors"
+	"gomo/matrix"
+	"math"
+)
+
+var (
+	// ErrInfeasible is returned when no point satisfies the limitations and the sign conditions
+	ErrInfeasible = errors.New("lpt: task has no feasible solution")
+	// ErrUnbounded is returned when the target function is unbounded on the feasible region
+	ErrUnbounded = errors.New("lpt: target function is unbounded")
+	// ErrNotConverged is returned when the simplex method makes too many iterations
+	ErrNotConverged = errors.New("lpt: simplex method didn't converge")
+)
+
+// SolutionTolerance is used to check the solutions Solve finds. The pivots are compared with
+// matrix.Epsilon, but the round-off grows with every iteration, so the results are checked looser
+var SolutionTolerance = 1e-6
+
+// column is a non-negative variable of the standard form, the task's variable is the sum of such columns
+// multiplied by their signs
+type column struct {
+	variable int
+	sign     float64
+}
+
+// columns maps the variables to the non-negative columns: x >= 0 stays, x <= 0 becomes -x'
+// and the variable without sign condition becomes the difference of two columns
+func (task LPT) columns(variables int) []column {
+	signs := make([]float64, variables)
+	for _, cond := range task.signConditions {
+		for i, value := range cond.operandsLeft {
+			if i >= variables || value == 0 {
+				continue
+			}
+
+			switch cond.operator {
+			case OperatorGreater, OperatorGreaterOrEqual:
+				signs[i] = math.Copysign(1, value)
+			case OperatorLess, OperatorLessOrEqual:
+				signs[i] = -math.Copysign(1, value)
+			}
+		}
+	}
+
+	columns := []column{}
+	for i, sign := range signs {
+		if sign == 0 {
+			columns = append(columns, column{i, 1}, column{i, -1})
+		} else {
+			columns = append(columns, column{i, sign})
+		}
+	}
+
+	return columns
+}
+
+// Solve solves the task with the two-phase simplex method. Bland's rule chooses the pivots,
+// so the method doesn't cycle on degenerate tasks. It returns the values of the variables
+// and the value of the target function
+func (task LPT) Solve() (matrix.Vector, float64, error) {
+	x, value, err := SolveOf[matrix.Float64](task)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	values := matrix.ShellV(len(x))
+	for i, el := range x {
+		values[i] = float64(el)
+	}
+
+	return values, float64(value), nil
+}
+
+// SolveOf is Solve with the arithmetic of the number type, matrix.Rational solves the task exactly
+func SolveOf[T matrix.Real[T]](task LPT) (matrix.VectorOf[T], T, error) {
+	var zero T
+	coeffs := matrix.VectorFrom[T](task.targetFunction.coeffs)
+	variables := len(coeffs)
+	for _, lim := range task.limitations {
+		if len(lim.operandsLeft) > variables {
+			variables = len(lim.operandsLeft)
+		}
+	}
+
+	columns := task.columns(variables)
+	n := len(columns)
+	h := len(task.limitations)
+
+	// every inequality gets a slack and every row gets an artificial variable
+	slacks := 0
+	for _, lim := range task.limitations {
+		if lim.operator != OperatorEqual {
+			slacks++
+		}
+	}
+
+	artificial := n + slacks
+	w := artificial + h + 1
+
+	tableau := matrix.ShellMOf[T](w, h+1)
+	basis := make([]int, h)
+
+	one := zero.One()
+	minusOne := zero.FromFloat64(-1)
+	slack := n
+	for y, lim := range task.limitations {
+		for x, c := range columns {
+			if c.variable < len(lim.operandsLeft) {
+				tableau[y][x] = zero.FromFloat64(c.sign * lim.operandsLeft[c.variable])
+			}
+		}
+		tableau[y][w-1] = zero.FromFloat64(lim.operandRight)
+
+		switch lim.operator {
+		case OperatorLess, OperatorLessOrEqual:
+			tableau[y][slack] = one
+			slack++
+		case OperatorGreater, OperatorGreaterOrEqual:
+			tableau[y][slack] = minusOne
+			slack++
+		}
+
+		if tableau[y][w-1].Sign() < 0 {
+			tableau[y] = tableau[y].MultiplyWithNumber(minusOne)
+		}
+
+		tableau[y][artificial+y] = one
+		basis[y] = artificial + y
+	}
+
+	tolerance := tableau.Tolerance()
+
+	// phase 1 minimizes the sum of the artificial variables
+	phase1 := matrix.ShellVOf[T](w - 1)
+	for x := artificial; x < w-1; x++ {
+		phase1[x] = one
+	}
+
+	tableau, err := pivot(tableau, basis, phase1, w-1, tolerance)
+	if err != nil {
+		return nil, zero, err
+	}
+
+	if isPositive(zero.Sub(tableau[h][w-1]), tolerance) {
+		return nil, zero, ErrInfeasible
+	}
+
+	// artificial variables left in the basis at zero level are replaced where possible
+	for y, x := range basis {
+		if x < artificial {
+			continue
+		}
+
+		for entering := 0; entering < artificial; entering++ {
+			if !isZero(tableau[y][entering], tolerance) {
+				tableau = tableau.BaseVector(y, entering)
+				basis[y] = entering
+				break
+			}
+		}
+	}
+
+	// phase 2 minimizes the target function and doesn't let the artificial variables enter
+	sign := 1.0
+	if task.targetFunction.bound == BoundMax {
+		sign = -1
+	}
+
+	phase2 := matrix.ShellVOf[T](w - 1)
+	for x, c := range columns {
+		if c.variable < len(coeffs) {
+			phase2[x] = zero.FromFloat64(sign * c.sign).Mul(coeffs[c.variable])
+		}
+	}
+
+	tableau, err = pivot(tableau, basis, phase2, artificial, tolerance)
+	if err != nil {
+		return nil, zero, err
+	}
+
+	values := matrix.ShellVOf[T](variables)
+	for y, x := range basis {
+		if x < n {
+			c := columns[x]
+			values[c.variable] = values[c.variable].Add(zero.FromFloat64(c.sign).Mul(tableau[y][w-1]))
+		}
+	}
+
+	value := coeffs.MultiplyElementByElement(values[:len(coeffs)]).Sum()
+
+	return values, value, nil
+}
+
+// isZero checks if the number is zero within the tolerance, exact numbers are compared with zero exactly
+func isZero[T matrix.Real[T]](value T, tolerance float64) bool {
+	return value.Sign() == 0 || value.Abs() <= tolerance
+}
+
+// isPositive checks if the number is above the tolerance
+func isPositive[T matrix.Real[T]](value T, tolerance float64) bool {
+	return value.Sign() > 0 && value.Abs() > tolerance
+}
+
+// pivot minimizes the target function over the tableau, the last row of the tableau is rewritten
+// with the reduced costs. Only the first columns count of variables can enter the basis,
+// the values within tolerance are treated as zeros
+func pivot[T matrix.Real[T]](tableau matrix.MatrixOf[T], basis []int, coeffs matrix.VectorOf[T], columns int, tolerance float64) (matrix.MatrixOf[T], error) {
+	w, h := tableau.Size()
+	last := w - 1
+	target := h - 1
+
+	// reduced costs are c - c_B * B^-1 * A, the right side keeps -Z
+	var zero T
+	for x := range tableau[target] {
+		tableau[target][x] = zero
+		if x < last {
+			tableau[target][x] = coeffs[x]
+		}
+	}
+	for y, x := range basis {
+		tableau = tableau.SubstractRow(y, target, coeffs[x])
+	}
+
+	for iteration := 0; ; iteration++ {
+		if iteration > 100*w*h {
+			return nil, ErrNotConverged
+		}
+
+		entering := -1
+		for x := 0; x < columns; x++ {
+			if isPositive(zero.Sub(tableau[target][x]), tolerance) {
+				entering = x
+				break
+			}
+		}
+
+		if entering == -1 {
+			return tableau, nil
+		}
+
+		leaving := matrix.PivotRowOf(tableau, entering, basis, tolerance)
+		if leaving == -1 {
+			return nil, ErrUnbounded
+		}
+
+		tableau = tableau.BaseVector(leaving, entering)
+		basis[leaving] = entering
+	}
+}
@@ -0,0 +1,190 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrDimensionMismatch is returned when sizes of the operands don't fit the operation
+	ErrDimensionMismatch = errors.New("matrix: dimension mismatch")
+	// ErrSingular is returned when the operation divides by a zero pivot
+	ErrSingular = errors.New("matrix: matrix is singular")
+	// ErrEmpty is returned when the matrix or the vector has no elements
+	ErrEmpty = errors.New("matrix: matrix is empty")
+	// ErrIndexOutOfRange is returned when a row or a column index is out of the matrix
+	ErrIndexOutOfRange = errors.New("matrix: index out of range")
+)
+
+// Validate checks that the matrix is not empty and every row has the same length
+func (m Matrix) Validate() error {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return ErrEmpty
+	}
+
+	for _, row := range m[1:] {
+		if len(row) != len(m[0]) {
+			return ErrDimensionMismatch
+		}
+	}
+
+	return nil
+}
+
+// validateSameSize checks that both matrices are valid and have the same size
+func validateSameSize(m1, m2 Matrix) error {
+	if err := m1.Validate(); err != nil {
+		return err
+	}
+
+	if err := m2.Validate(); err != nil {
+		return err
+	}
+
+	if w1, h1 := m1.Size(); w1 != m2.Width() || h1 != m2.Height() {
+		return ErrDimensionMismatch
+	}
+
+	return nil
+}
+
+// AddE is Add returning an error on different sizes
+func AddE(m1, m2 Matrix) (Matrix, error) {
+	if err := validateSameSize(m1, m2); err != nil {
+		return nil, err
+	}
+
+	return Add(m1, m2), nil
+}
+
+// SubstractE is Substract returning an error on different sizes
+func SubstractE(m1, m2 Matrix) (Matrix, error) {
+	if err := validateSameSize(m1, m2); err != nil {
+		return nil, err
+	}
+
+	return Substract(m1, m2), nil
+}
+
+// MulE is Multiply returning an error when width of m1 doesn't equal height of m2
+func MulE(m1, m2 Matrix) (Matrix, error) {
+	if err := m1.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := m2.Validate(); err != nil {
+		return nil, err
+	}
+
+	if m1.Width() != m2.Height() {
+		return nil, ErrDimensionMismatch
+	}
+
+	return Multiply(m1, m2), nil
+}
+
+// FillWithE is FillWith returning an error when m2 doesn't fit into m
+func (m Matrix) FillWithE(m2 Matrix) (Matrix, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(m2) > m.Height() {
+		return nil, ErrDimensionMismatch
+	}
+
+	for _, row := range m2 {
+		if len(row) > m.Width() {
+			return nil, ErrDimensionMismatch
+		}
+	}
+
+	return m.FillWith(m2), nil
+}
+
+// FillWithE is FillWith returning an error when v2 is longer than v
+func (v Vector) FillWithE(v2 Vector) (Vector, error) {
+	if len(v2) > len(v) {
+		return nil, ErrDimensionMismatch
+	}
+
+	return v.FillWith(v2), nil
+}
+
+// MultiplyElementByElementE is MultiplyElementByElement returning an error on different lengths
+func (v1 Vector) MultiplyElementByElementE(v2 Vector) (Vector, error) {
+	if len(v1) == 0 {
+		return nil, ErrEmpty
+	}
+
+	if len(v1) != len(v2) {
+		return nil, ErrDimensionMismatch
+	}
+
+	return v1.MultiplyElementByElement(v2), nil
+}
+
+// checkRow checks the matrix and the row index
+func (m Matrix) checkRow(rowIndex int) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	if rowIndex < 0 || rowIndex >= m.Height() {
+		return ErrIndexOutOfRange
+	}
+
+	return nil
+}
+
+// DivideRowE is DivideRow returning an error on wrong index or zero value
+func (m Matrix) DivideRowE(rowIndex int, value float64) (Matrix, error) {
+	if err := m.checkRow(rowIndex); err != nil {
+		return nil, err
+	}
+
+	if IsZero(value) {
+		return nil, ErrSingular
+	}
+
+	return m.DivideRow(rowIndex, value), nil
+}
+
+// SubstractRowE is SubstractRow returning an error on wrong indexes
+func (m Matrix) SubstractRowE(rowIndexWhich int, rowIndexFrom int, multiplier float64) (Matrix, error) {
+	if err := m.checkRow(rowIndexWhich); err != nil {
+		return nil, err
+	}
+
+	if err := m.checkRow(rowIndexFrom); err != nil {
+		return nil, err
+	}
+
+	return m.SubstractRow(rowIndexWhich, rowIndexFrom, multiplier), nil
+}
+
+// BaseVectorE is BaseVector returning an error on wrong indexes or zero pivot
+func (m Matrix) BaseVectorE(rowIndex, columnIndex int) (Matrix, error) {
+	if err := m.checkRow(rowIndex); err != nil {
+		return nil, err
+	}
+
+	if columnIndex < 0 || columnIndex >= m.Width() {
+		return nil, ErrIndexOutOfRange
+	}
+
+	if math.Abs(m[rowIndex][columnIndex]) <= Epsilon*math.Max(m.maxAbs(), 1) {
+		return nil, ErrSingular
+	}
+
+	return m.BaseVector(rowIndex, columnIndex), nil
+}
+
+// TransposeE is Transpose returning an error on empty or ragged matrix
+func (m Matrix) TransposeE() (Matrix, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m.Transpose(), nil
+}
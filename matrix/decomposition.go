@@ -0,0 +1,480 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNotPositiveDefinite is returned when Cholesky decomposition meets a non-positive pivot
+var ErrNotPositiveDefinite = errors.New("matrix: matrix is not symmetric positive definite")
+
+// LU is the decomposition P * A = L * U with partial pivoting,
+// L is unit lower triangular and U is upper triangular
+type LU struct {
+	l           Matrix
+	u           Matrix
+	permutation []int
+	sign        float64
+}
+
+// QR is the decomposition A = Q * R made with Householder reflections,
+// Q is orthogonal m x m and R is upper triangular m x n
+type QR struct {
+	q    Matrix
+	r    Matrix
+	sign float64
+}
+
+// Cholesky is the decomposition A = L * L^T of a symmetric positive definite matrix
+type Cholesky struct {
+	l Matrix
+}
+
+// NewLU factors the square matrix, singular matrix is factored too but can't be solved
+func NewLU(m Matrix) (LU, error) {
+	if err := m.validateSquare(); err != nil {
+		return LU{}, err
+	}
+
+	n := m.Height()
+	u := m.Clone()
+	l := Identity(n)
+	permutation := make([]int, n)
+	for i := range permutation {
+		permutation[i] = i
+	}
+	sign := 1.0
+	tolerance := Epsilon * math.Max(u.maxAbs(), 1)
+
+	for x := 0; x < n; x++ {
+		pivot := x
+		for y := x + 1; y < n; y++ {
+			if math.Abs(u[y][x]) > math.Abs(u[pivot][x]) {
+				pivot = y
+			}
+		}
+
+		if pivot != x {
+			u[x], u[pivot] = u[pivot], u[x]
+			permutation[x], permutation[pivot] = permutation[pivot], permutation[x]
+			for k := 0; k < x; k++ {
+				l[x][k], l[pivot][k] = l[pivot][k], l[x][k]
+			}
+			sign = -sign
+		}
+
+		// the rest of the column is zero within the tolerance, so there is nothing to eliminate
+		if math.Abs(u[x][x]) <= tolerance {
+			for y := x + 1; y < n; y++ {
+				u[y][x] = 0
+			}
+			continue
+		}
+
+		// the rows are updated in place, the columns before x are already zero
+		for y := x + 1; y < n; y++ {
+			l[y][x] = u[y][x] / u[x][x]
+			for k := x; k < n; k++ {
+				u[y][k] -= l[y][x] * u[x][k]
+			}
+		}
+	}
+
+	return LU{l: l, u: u, permutation: permutation, sign: sign}, nil
+}
+
+// L returns the unit lower triangular factor
+func (lu LU) L() Matrix {
+	return lu.l.Clone()
+}
+
+// U returns the upper triangular factor
+func (lu LU) U() Matrix {
+	return lu.u.Clone()
+}
+
+// P returns the permutation matrix
+func (lu LU) P() Matrix {
+	p := ShellM(len(lu.permutation), len(lu.permutation))
+	for y, x := range lu.permutation {
+		p[y][x] = 1
+	}
+
+	return p
+}
+
+// Det returns the determinant of the factored matrix
+func (lu LU) Det() float64 {
+	det := lu.sign
+	for i, row := range lu.u {
+		det *= row[i]
+	}
+
+	return det
+}
+
+// Solve solves A * x = b with forward and back substitution
+func (lu LU) Solve(b Vector) (Vector, error) {
+	n := len(lu.u)
+	if len(b) != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	tolerance := Epsilon * math.Max(lu.u.maxAbs(), 1)
+
+	y := ShellV(n)
+	for i, p := range lu.permutation {
+		y[i] = b[p]
+		for k := 0; k < i; k++ {
+			y[i] -= lu.l[i][k] * y[k]
+		}
+	}
+
+	return backSubstitution(lu.u, y, tolerance)
+}
+
+// backSubstitution solves the upper triangular n x n system, the matrix may have more rows or columns
+func backSubstitution(u Matrix, b Vector, tolerance float64) (Vector, error) {
+	n := len(b)
+
+	x := ShellV(n)
+	for i := n - 1; i >= 0; i-- {
+		if math.Abs(u[i][i]) <= tolerance {
+			return nil, ErrSingular
+		}
+
+		x[i] = b[i]
+		for k := i + 1; k < n; k++ {
+			x[i] -= u[i][k] * x[k]
+		}
+		x[i] /= u[i][i]
+	}
+
+	return x, nil
+}
+
+// Update updates the decomposition to the matrix A + x * y^T in O(n^2) (Bennett's algorithm).
+// The pivoting isn't changed, so the factors of a badly pivoted update may lose accuracy
+func (lu LU) Update(x, y Vector) (LU, error) {
+	n := len(lu.u)
+	if len(x) != n || len(y) != n {
+		return LU{}, ErrDimensionMismatch
+	}
+
+	l := lu.l.Clone()
+	u := lu.u.Clone()
+
+	// P * (A + x * y^T) = L * U + (P * x) * y^T
+	px := ShellV(n)
+	for i, p := range lu.permutation {
+		px[i] = x[p]
+	}
+	y = y.Clone()
+	tolerance := Epsilon * math.Max(u.maxAbs(), 1)
+
+	for i := 0; i < n; i++ {
+		u[i][i] += px[i] * y[i]
+		if math.Abs(u[i][i]) <= tolerance {
+			return LU{}, ErrSingular
+		}
+
+		beta := y[i] / u[i][i]
+		for j := i + 1; j < n; j++ {
+			u[i][j] += px[i] * y[j]
+
+			px[j] -= px[i] * l[j][i]
+			l[j][i] += beta * px[j]
+		}
+
+		for j := i + 1; j < n; j++ {
+			y[j] -= beta * u[i][j]
+		}
+	}
+
+	return LU{l: l, u: u, permutation: append([]int{}, lu.permutation...), sign: lu.sign}, nil
+}
+
+// givens returns the rotation [c s; -s c] making the vector (a, b) equal to (r, 0)
+func givens(a, b float64) (float64, float64) {
+	r := math.Hypot(a, b)
+	if r == 0 {
+		return 1, 0
+	}
+
+	return a / r, b / r
+}
+
+// rotateRows applies the rotation to the rows i and j of the matrix in place
+func rotateRows(m Matrix, i, j int, c, s float64) {
+	for x := range m[i] {
+		mi, mj := m[i][x], m[j][x]
+		m[i][x] = c*mi + s*mj
+		m[j][x] = -s*mi + c*mj
+	}
+}
+
+// rotateColumns applies the transposed rotation to the columns i and j of the matrix in place
+func rotateColumns(m Matrix, i, j int, c, s float64) {
+	for y := range m {
+		mi, mj := m[y][i], m[y][j]
+		m[y][i] = c*mi + s*mj
+		m[y][j] = -s*mi + c*mj
+	}
+}
+
+// NewQR factors the matrix with at least as many rows as columns
+func NewQR(m Matrix) (QR, error) {
+	if err := m.Validate(); err != nil {
+		return QR{}, err
+	}
+
+	w, h := m.Size()
+	if h < w {
+		return QR{}, ErrDimensionMismatch
+	}
+
+	r := m.Clone()
+	q := Identity(h)
+	sign := 1.0
+
+	for x := 0; x < w && x < h-1; x++ {
+		// v is the Householder vector reflecting the column below the diagonal to the axis
+		norm := 0.0
+		for y := x; y < h; y++ {
+			norm = math.Hypot(norm, r[y][x])
+		}
+
+		if norm == 0 {
+			continue
+		}
+
+		if r[x][x] > 0 {
+			norm = -norm
+		}
+
+		v := ShellV(h)
+		for y := x; y < h; y++ {
+			v[y] = r[y][x]
+		}
+		v[x] -= norm
+
+		vv := v.MultiplyElementByElement(v).Sum()
+		if vv == 0 {
+			continue
+		}
+
+		// H = I - 2 v v^T / (v^T v), R = H * R, Q = Q * H
+		for column := x; column < w; column++ {
+			factor := 2 * r.GetColumn(column).MultiplyElementByElement(v).Sum() / vv
+			for y := x; y < h; y++ {
+				r[y][column] -= factor * v[y]
+			}
+		}
+
+		for _, row := range q {
+			factor := 2 * row.MultiplyElementByElement(v).Sum() / vv
+			for y := x; y < h; y++ {
+				row[y] -= factor * v[y]
+			}
+		}
+
+		for y := x + 1; y < h; y++ {
+			r[y][x] = 0
+		}
+
+		sign = -sign
+	}
+
+	return QR{q: q, r: r, sign: sign}, nil
+}
+
+// Q returns the orthogonal factor
+func (qr QR) Q() Matrix {
+	return qr.q.Clone()
+}
+
+// R returns the upper triangular factor
+func (qr QR) R() Matrix {
+	return qr.r.Clone()
+}
+
+// Det returns the determinant of the factored square matrix
+func (qr QR) Det() (float64, error) {
+	if qr.r.Width() != qr.r.Height() {
+		return 0, ErrDimensionMismatch
+	}
+
+	det := qr.sign
+	for i, row := range qr.r {
+		det *= row[i]
+	}
+
+	return det, nil
+}
+
+// Solve solves A * x = b, the least squares solution is returned for systems with more rows than columns
+func (qr QR) Solve(b Vector) (Vector, error) {
+	w, h := qr.r.Size()
+	if len(b) != h {
+		return nil, ErrDimensionMismatch
+	}
+
+	// Q^T * b
+	qtb := ShellV(w)
+	for i := 0; i < w; i++ {
+		qtb[i] = qr.q.GetColumn(i).MultiplyElementByElement(b).Sum()
+	}
+
+	return backSubstitution(qr.r, qtb, Epsilon*math.Max(qr.r.maxAbs(), 1))
+}
+
+// Update updates the decomposition to the matrix A + u * v^T in O(m^2) with Givens rotations
+func (qr QR) Update(u, v Vector) (QR, error) {
+	w, h := qr.r.Size()
+	if len(u) != h || len(v) != w {
+		return QR{}, ErrDimensionMismatch
+	}
+
+	q := qr.q.Clone()
+	r := qr.r.Clone()
+
+	// A + u * v^T = Q * (R + z * v^T), z = Q^T * u
+	z := ShellV(h)
+	for i := range z {
+		z[i] = q.GetColumn(i).MultiplyElementByElement(u).Sum()
+	}
+
+	// z is rotated to the first axis, R becomes upper Hessenberg
+	for k := h - 1; k > 0; k-- {
+		c, s := givens(z[k-1], z[k])
+		z[k-1], z[k] = c*z[k-1]+s*z[k], 0
+		rotateRows(r, k-1, k, c, s)
+		rotateColumns(q, k-1, k, c, s)
+	}
+
+	for x := range r[0] {
+		r[0][x] += z[0] * v[x]
+	}
+
+	// Hessenberg matrix is made triangular back
+	for k := 0; k < w && k < h-1; k++ {
+		c, s := givens(r[k][k], r[k+1][k])
+		rotateRows(r, k, k+1, c, s)
+		rotateColumns(q, k, k+1, c, s)
+		r[k+1][k] = 0
+	}
+
+	return QR{q: q, r: r, sign: qr.sign}, nil
+}
+
+// NewCholesky factors the symmetric positive definite matrix
+func NewCholesky(m Matrix) (Cholesky, error) {
+	if err := m.validateSquare(); err != nil {
+		return Cholesky{}, err
+	}
+
+	n := m.Height()
+	tolerance := Epsilon * math.Max(m.maxAbs(), 1)
+	for y := 0; y < n; y++ {
+		for x := 0; x < y; x++ {
+			if math.Abs(m[y][x]-m[x][y]) > tolerance {
+				return Cholesky{}, ErrNotPositiveDefinite
+			}
+		}
+	}
+
+	l := ShellM(n, n)
+	for y := 0; y < n; y++ {
+		for x := 0; x <= y; x++ {
+			sum := m[y][x]
+			for k := 0; k < x; k++ {
+				sum -= l[y][k] * l[x][k]
+			}
+
+			if x == y {
+				if sum <= tolerance {
+					return Cholesky{}, ErrNotPositiveDefinite
+				}
+				l[y][y] = math.Sqrt(sum)
+			} else {
+				l[y][x] = sum / l[x][x]
+			}
+		}
+	}
+
+	return Cholesky{l: l}, nil
+}
+
+// L returns the lower triangular factor
+func (ch Cholesky) L() Matrix {
+	return ch.l.Clone()
+}
+
+// Det returns the determinant of the factored matrix
+func (ch Cholesky) Det() float64 {
+	det := 1.0
+	for i, row := range ch.l {
+		det *= row[i] * row[i]
+	}
+
+	return det
+}
+
+// Solve solves A * x = b solving L * y = b and L^T * x = y
+func (ch Cholesky) Solve(b Vector) (Vector, error) {
+	n := len(ch.l)
+	if len(b) != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	y := ShellV(n)
+	for i := 0; i < n; i++ {
+		y[i] = b[i]
+		for k := 0; k < i; k++ {
+			y[i] -= ch.l[i][k] * y[k]
+		}
+		y[i] /= ch.l[i][i]
+	}
+
+	return backSubstitution(ch.l.Transpose(), y, 0)
+}
+
+// Update updates the decomposition to the matrix A + x * x^T in O(n^2)
+func (ch Cholesky) Update(x Vector) (Cholesky, error) {
+	return ch.rankOne(x, 1)
+}
+
+// Downdate updates the decomposition to the matrix A - x * x^T in O(n^2),
+// ErrNotPositiveDefinite is returned when the result isn't positive definite
+func (ch Cholesky) Downdate(x Vector) (Cholesky, error) {
+	return ch.rankOne(x, -1)
+}
+
+func (ch Cholesky) rankOne(x Vector, sign float64) (Cholesky, error) {
+	n := len(ch.l)
+	if len(x) != n {
+		return Cholesky{}, ErrDimensionMismatch
+	}
+
+	l := ch.l.Clone()
+	x = x.Clone()
+
+	for k := 0; k < n; k++ {
+		r2 := l[k][k]*l[k][k] + sign*x[k]*x[k]
+		if r2 <= 0 {
+			return Cholesky{}, ErrNotPositiveDefinite
+		}
+
+		r := math.Sqrt(r2)
+		c := r / l[k][k]
+		s := x[k] / l[k][k]
+		l[k][k] = r
+
+		for i := k + 1; i < n; i++ {
+			l[i][k] = (l[i][k] + sign*s*x[i]) / c
+			x[i] = c*x[i] - s*l[i][k]
+		}
+	}
+
+	return Cholesky{l: l}, nil
+}
@@ -0,0 +1,437 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// maxSweeps limits the sweeps of Jacobi rotations and the QR algorithm iterations per eigenvalue
+const maxSweeps = 100
+
+var (
+	// ErrNotSymmetric is returned when the matrix must be symmetric but it isn't
+	ErrNotSymmetric = errors.New("matrix: matrix is not symmetric")
+	// ErrNotConverged is returned when the iterative method hasn't converged in the iterations limit
+	ErrNotConverged = errors.New("matrix: iterations have not converged")
+)
+
+// Eigen is the decomposition A = V * diag(values) * V^T of a symmetric matrix,
+// V is orthogonal and the eigenvalues are sorted in descending order
+type Eigen struct {
+	values  Vector
+	vectors Matrix
+}
+
+// norm returns the euclidean norm of the vector
+func (v Vector) norm() float64 {
+	norm := 0.0
+	for _, value := range v {
+		norm = math.Hypot(norm, value)
+	}
+
+	return norm
+}
+
+// multiplyVector returns the product of the matrix and the column vector
+func (m Matrix) multiplyVector(v Vector) Vector {
+	product := ShellV(m.Height())
+	for y, row := range m {
+		product[y] = row.MultiplyElementByElement(v).Sum()
+	}
+
+	return product
+}
+
+// isSymmetric checks the matrix is symmetric within the tolerance
+func (m Matrix) isSymmetric(tolerance float64) bool {
+	for y, row := range m {
+		for x := 0; x < y; x++ {
+			if math.Abs(row[x]-m[x][y]) > tolerance {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// sortColumns sorts the values in descending order and reorders the columns of the matrices the same way
+func sortColumns(values Vector, matrices ...Matrix) (Vector, []Matrix) {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	sortedValues := ShellV(len(values))
+	for i, index := range order {
+		sortedValues[i] = values[index]
+	}
+
+	sorted := make([]Matrix, len(matrices))
+	for k, m := range matrices {
+		sorted[k] = ShellM(len(order), m.Height())
+		for y, row := range m {
+			for x, index := range order {
+				sorted[k][y][x] = row[index]
+			}
+		}
+	}
+
+	return sortedValues, sorted
+}
+
+// NewEigenSymmetric finds the eigenvalues and the eigenvectors of the symmetric matrix with Jacobi rotations
+func NewEigenSymmetric(m Matrix) (Eigen, error) {
+	if err := m.validateSquare(); err != nil {
+		return Eigen{}, err
+	}
+
+	tolerance := Epsilon * math.Max(m.maxAbs(), 1)
+	if !m.isSymmetric(tolerance) {
+		return Eigen{}, ErrNotSymmetric
+	}
+
+	n := m.Height()
+	a := m.Clone()
+	v := Identity(n)
+
+	for sweep := 0; ; sweep++ {
+		off := 0.0
+		for y := range a {
+			for x := 0; x < y; x++ {
+				off = math.Hypot(off, a[y][x])
+			}
+		}
+
+		if off <= tolerance {
+			break
+		}
+
+		if sweep == maxSweeps {
+			return Eigen{}, ErrNotConverged
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				// the smaller elements can't keep the off-diagonal norm above the tolerance
+				if math.Abs(a[p][q]) <= tolerance/float64(n) {
+					continue
+				}
+
+				// the rotation J^T * A * J zeroes the elements at (p, q) and (q, p)
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Hypot(theta, 1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Hypot(t, 1)
+				s := t * c
+
+				rotateColumns(a, p, q, c, -s)
+				rotateRows(a, p, q, c, -s)
+				rotateColumns(v, p, q, c, -s)
+				a[p][q], a[q][p] = 0, 0
+			}
+		}
+	}
+
+	values := ShellV(n)
+	for i := range values {
+		values[i] = a[i][i]
+	}
+
+	values, sorted := sortColumns(values, v)
+	return Eigen{values: values, vectors: sorted[0]}, nil
+}
+
+// Values returns the eigenvalues in descending order
+func (e Eigen) Values() Vector {
+	return e.values.Clone()
+}
+
+// Vectors returns the matrix with the unit eigenvectors as the columns
+func (e Eigen) Vectors() Matrix {
+	return e.vectors.Clone()
+}
+
+// PowerIteration finds the eigenvalue of the biggest absolute value and its unit eigenvector.
+// The iterations stop when |A * v - value * v| is not more than the tolerance,
+// ErrNotConverged is returned along with the last approximation otherwise
+func (m Matrix) PowerIteration(iterations int, tolerance float64) (float64, Vector, error) {
+	if err := m.validateSquare(); err != nil {
+		return 0, nil, err
+	}
+
+	n := m.Height()
+
+	// unequal components make the start vector unlikely to be orthogonal to the eigenvector
+	v := ShellV(n)
+	for i := range v {
+		v[i] = 1 + float64(i)/float64(n)
+	}
+	v = v.MultiplyWithNumber(1 / v.norm())
+
+	value := 0.0
+	for k := 0; k < iterations; k++ {
+		product := m.multiplyVector(v)
+		value = product.MultiplyElementByElement(v).Sum()
+
+		residual := product.Clone()
+		for i := range residual {
+			residual[i] -= value * v[i]
+		}
+
+		if residual.norm() <= tolerance {
+			return value, v, nil
+		}
+
+		v = product.MultiplyWithNumber(1 / product.norm())
+	}
+
+	return value, v, ErrNotConverged
+}
+
+// eigenvalues2 returns the eigenvalues of the matrix [a b; c d]
+func eigenvalues2(a, b, c, d float64) (complex128, complex128) {
+	mean := (a + d) / 2
+	discriminant := (a-d)*(a-d)/4 + b*c
+	if discriminant < 0 {
+		root := math.Sqrt(-discriminant)
+		return complex(mean, root), complex(mean, -root)
+	}
+
+	root := math.Sqrt(discriminant)
+	return complex(mean+root, 0), complex(mean-root, 0)
+}
+
+// hessenberg reduces the square matrix to the upper Hessenberg form Q^T * A * Q with Householder reflections,
+// the elements below the subdiagonal are zeros and the eigenvalues stay the same
+func (m Matrix) hessenberg() Matrix {
+	a := m.Clone()
+	n := len(a)
+
+	for x := 0; x < n-2; x++ {
+		// v is the Householder vector reflecting the column below the subdiagonal to the axis
+		norm := 0.0
+		for y := x + 1; y < n; y++ {
+			norm = math.Hypot(norm, a[y][x])
+		}
+
+		if norm == 0 {
+			continue
+		}
+
+		if a[x+1][x] > 0 {
+			norm = -norm
+		}
+
+		v := ShellV(n)
+		for y := x + 1; y < n; y++ {
+			v[y] = a[y][x]
+		}
+		v[x+1] -= norm
+
+		vv := v.MultiplyElementByElement(v).Sum()
+		if vv == 0 {
+			continue
+		}
+
+		// H = I - 2 v v^T / (v^T v), A = H * A * H
+		for column := x; column < n; column++ {
+			factor := 2 * a.GetColumn(column).MultiplyElementByElement(v).Sum() / vv
+			for y := x + 1; y < n; y++ {
+				a[y][column] -= factor * v[y]
+			}
+		}
+
+		for _, row := range a {
+			factor := 2 * row.MultiplyElementByElement(v).Sum() / vv
+			for y := x + 1; y < n; y++ {
+				row[y] -= factor * v[y]
+			}
+		}
+
+		for y := x + 2; y < n; y++ {
+			a[y][x] = 0
+		}
+	}
+
+	return a
+}
+
+// Eigenvalues finds the eigenvalues of the general real matrix. The matrix is reduced to the Hessenberg form
+// and the eigenvalues are separated by the QR algorithm with Francis double shifts, so the complex pairs
+// are found in real arithmetic. Complex eigenvalues come in conjugate pairs,
+// the eigenvalues are sorted by the real and the imaginary parts
+func (m Matrix) Eigenvalues() ([]complex128, error) {
+	if err := m.validateSquare(); err != nil {
+		return nil, err
+	}
+
+	tolerance := Epsilon * math.Max(m.maxAbs(), 1)
+	values := []complex128{}
+
+	a := m.hessenberg()
+	norm := a.maxAbs()
+
+	// shift is the sum of the exceptional shifts subtracted from the diagonal
+	shift := 0.0
+	for last, iteration := len(a)-1, 0; last >= 0; {
+		// the active block starts after the last negligible subdiagonal element
+		first := last
+		for ; first > 0; first-- {
+			scale := math.Abs(a[first-1][first-1]) + math.Abs(a[first][first])
+			if scale == 0 {
+				scale = norm
+			}
+
+			if math.Abs(a[first][first-1]) <= Epsilon*scale {
+				a[first][first-1] = 0
+				break
+			}
+		}
+
+		if first == last {
+			values = append(values, complex(a[last][last]+shift, 0))
+			last--
+			iteration = 0
+			continue
+		}
+
+		if first == last-1 {
+			v1, v2 := eigenvalues2(a[first][first]+shift, a[first][last], a[last][first], a[last][last]+shift)
+			values = append(values, v1, v2)
+			last -= 2
+			iteration = 0
+			continue
+		}
+
+		if iteration == maxSweeps {
+			return nil, ErrNotConverged
+		}
+		iteration++
+
+		// x + y and x * y - w are the sum and the product of the shifts, they are the eigenvalues
+		// of the trailing 2x2 block. The exceptional shifts break the cycles
+		x, y, w := a[last][last], a[last-1][last-1], a[last][last-1]*a[last-1][last]
+		if iteration%10 == 0 {
+			shift += x
+			for i := 0; i <= last; i++ {
+				a[i][i] -= x
+			}
+
+			s := math.Abs(a[last][last-1]) + math.Abs(a[last-1][last-2])
+			x, y, w = 0.75*s, 0.75*s, -0.4375*s*s
+		}
+
+		francisStep(a, first, last, x, y, w)
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		if real(values[i]) != real(values[j]) {
+			return real(values[i]) > real(values[j])
+		}
+
+		return imag(values[i]) > imag(values[j])
+	})
+
+	for i, value := range values {
+		if math.Abs(imag(value)) <= tolerance {
+			values[i] = complex(real(value), 0)
+		}
+	}
+
+	return values, nil
+}
+
+// francisStep makes the double shift QR step on the active block [first, last] of the Hessenberg matrix in place.
+// The shifts are the roots of t^2 - (x + y) t + x * y - w
+func francisStep(a Matrix, first, last int, x, y, w float64) {
+	// the step starts from the row after which two consecutive subdiagonal elements are small enough
+	start := last - 2
+	var p, q, r float64
+	for ; start >= first; start-- {
+		z := a[start][start]
+		r = x - z
+		s := y - z
+		p = (r*s-w)/a[start+1][start] + a[start][start+1]
+		q = a[start+1][start+1] - z - r - s
+		r = a[start+2][start+1]
+
+		s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+		p, q, r = p/s, q/s, r/s
+		if start == first {
+			break
+		}
+
+		u := math.Abs(a[start][start-1]) * (math.Abs(q) + math.Abs(r))
+		v := math.Abs(p) * (math.Abs(a[start-1][start-1]) + math.Abs(z) + math.Abs(a[start+1][start+1]))
+		if u <= Epsilon*v {
+			break
+		}
+	}
+
+	for i := start + 2; i <= last; i++ {
+		a[i][i-2] = 0
+		if i != start+2 {
+			a[i][i-3] = 0
+		}
+	}
+
+	// the bulge is chased down the subdiagonal with the Householder reflections of three rows
+	for k := start; k < last; k++ {
+		scale := 0.0
+		if k != start {
+			p, q, r = a[k][k-1], a[k+1][k-1], 0
+			if k+1 != last {
+				r = a[k+2][k-1]
+			}
+
+			scale = math.Abs(p) + math.Abs(q) + math.Abs(r)
+			if scale != 0 {
+				p, q, r = p/scale, q/scale, r/scale
+			}
+		}
+
+		s := math.Copysign(math.Sqrt(p*p+q*q+r*r), p)
+		if s == 0 {
+			continue
+		}
+
+		if k == start {
+			if first != start {
+				a[k][k-1] = -a[k][k-1]
+			}
+		} else {
+			a[k][k-1] = -s * scale
+		}
+
+		p += s
+		hx, hy, hz := p/s, q/s, r/s
+		q, r = q/p, r/p
+
+		for j := k; j <= last; j++ {
+			value := a[k][j] + q*a[k+1][j]
+			if k+1 != last {
+				value += r * a[k+2][j]
+				a[k+2][j] -= value * hz
+			}
+			a[k+1][j] -= value * hy
+			a[k][j] -= value * hx
+		}
+
+		for i := first; i <= minInt(last, k+3); i++ {
+			value := hx*a[i][k] + hy*a[i][k+1]
+			if k+1 != last {
+				value += hz * a[i][k+2]
+				a[i][k+2] -= value * r
+			}
+			a[i][k+1] -= value * q
+			a[i][k] -= value
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Style is the table layout of Formatter
+type Style int
+
+const (
+	// StylePlain aligns the cells with spaces
+	StylePlain Style = iota
+	// StyleMarkdown makes Markdown table
+	StyleMarkdown
+	// StyleLaTeX makes LaTeX tabular environment
+	StyleLaTeX
+	// StyleCSV makes comma separated values without alignment
+	StyleCSV
+)
+
+const (
+	// humanizeTolerance is the distance to the closest integer the value is shown as integer from
+	humanizeTolerance = 10e-3
+	// fractionTolerance is the distance to the fraction the value is shown as the fraction from
+	fractionTolerance = 1e-9
+	// defaultMaxDenominator is used when MaxDenominator isn't set
+	defaultMaxDenominator = 100
+)
+
+// Formatter formats the values, the vectors and the matrices
+type Formatter struct {
+	// Precision is the count of digits after the point, negative precision means the shortest exact form
+	Precision int
+	// Width is the minimal width of the cells, the cells of every column are aligned to the widest one
+	Width int
+	// Humanize shows the values close to integers without the fractional part
+	Humanize bool
+	// Fractions shows the values as fractions like 2/3 when the denominator is not more than MaxDenominator
+	Fractions      bool
+	MaxDenominator int
+	// RowLabels and ColumnLabels are printed before the rows and above the columns when they are set
+	RowLabels    []string
+	ColumnLabels []string
+	Style        Style
+}
+
+var (
+	// markdownEscaper escapes the characters breaking Markdown table cells
+	markdownEscaper = strings.NewReplacer(`|`, `\|`, `_`, `\_`)
+	// latexEscaper escapes the special characters of LaTeX
+	latexEscaper = strings.NewReplacer(`\`, `\textbackslash{}`, `&`, `\&`, `%`, `\%`, `$`, `\$`, `#`, `\#`, `_`, `\_`,
+		`{`, `\{`, `}`, `\}`, `~`, `\textasciitilde{}`, `^`, `\textasciicircum{}`)
+)
+
+// DefaultFormatter is used by Matrix.String, Vector.String, LPT.String and the simplex traces
+var DefaultFormatter = Formatter{Precision: 3, Width: 6}
+
+// fraction returns the value as the fraction with the smallest denominator up to maxDenominator
+func fraction(value float64, maxDenominator int) (string, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "", false
+	}
+
+	for denominator := 1; denominator <= maxDenominator; denominator++ {
+		numerator := math.Round(value * float64(denominator))
+		if math.Abs(value-numerator/float64(denominator)) > fractionTolerance*math.Max(1, math.Abs(value)) {
+			continue
+		}
+
+		// adding zero turns -0 into 0
+		numerator += 0
+		if denominator == 1 {
+			return strconv.FormatFloat(numerator, 'f', 0, 64), true
+		}
+
+		return fmt.Sprintf("%.0f/%d", numerator, denominator), true
+	}
+
+	return "", false
+}
+
+// FormatValue formats the single value without padding
+func (f Formatter) FormatValue(value float64) string {
+	if f.Fractions {
+		maxDenominator := f.MaxDenominator
+		if maxDenominator <= 0 {
+			maxDenominator = defaultMaxDenominator
+		}
+
+		if s, ok := fraction(value, maxDenominator); ok {
+			return s
+		}
+	}
+
+	if f.Humanize && math.Abs(value-math.Round(value)) < humanizeTolerance {
+		return strconv.FormatFloat(math.Round(value)+0, 'f', 0, 64)
+	}
+
+	return strconv.FormatFloat(value, 'f', f.Precision, 64)
+}
+
+// FormatVector formats the vector as the table of one row
+func (f Formatter) FormatVector(v Vector) string {
+	return strings.TrimSuffix(f.FormatTable([][]string{f.formatRow(v)}), "\n")
+}
+
+// FormatMatrix formats the matrix as the table
+func (f Formatter) FormatMatrix(m Matrix) string {
+	cells := make([][]string, len(m))
+	for y, row := range m {
+		cells[y] = f.formatRow(row)
+	}
+
+	return f.FormatTable(cells)
+}
+
+// formatRow formats every value of the row
+func (f Formatter) formatRow(v Vector) []string {
+	cells := make([]string, len(v))
+	for x, value := range v {
+		cells[x] = f.FormatValue(value)
+	}
+
+	return cells
+}
+
+// FormatTable lays out the formatted cells with the labels in the style of the formatter
+func (f Formatter) FormatTable(cells [][]string) string {
+	rows := [][]string{}
+	if f.ColumnLabels != nil || f.Style == StyleMarkdown {
+		header := make([]string, len(f.ColumnLabels))
+		copy(header, f.ColumnLabels)
+
+		// Markdown table can't go without the header, so the empty one is added
+		for len(cells) > 0 && len(header) < len(cells[0]) {
+			header = append(header, "")
+		}
+
+		if f.RowLabels != nil {
+			header = append([]string{""}, header...)
+		}
+		rows = append(rows, header)
+	}
+
+	for y, row := range cells {
+		if f.RowLabels != nil {
+			label := ""
+			if y < len(f.RowLabels) {
+				label = f.RowLabels[y]
+			}
+			row = append([]string{label}, row...)
+		}
+		rows = append(rows, row)
+	}
+
+	switch f.Style {
+	case StyleMarkdown:
+		rows = escape(rows, markdownEscaper)
+	case StyleLaTeX:
+		rows = escape(rows, latexEscaper)
+	}
+
+	switch f.Style {
+	case StyleCSV:
+		var b strings.Builder
+		writer := csv.NewWriter(&b)
+		writer.WriteAll(rows)
+		return b.String()
+	case StyleMarkdown:
+		return f.markdown(rows)
+	case StyleLaTeX:
+		return f.latex(rows)
+	}
+
+	return f.plain(rows)
+}
+
+// escape returns the copy of the rows with the cells escaped by the replacer
+func escape(rows [][]string, replacer *strings.Replacer) [][]string {
+	escaped := make([][]string, len(rows))
+	for y, row := range rows {
+		escaped[y] = make([]string, len(row))
+		for x, cell := range row {
+			escaped[y][x] = replacer.Replace(cell)
+		}
+	}
+
+	return escaped
+}
+
+// widths returns the width of every column, it's not less than Width
+func (f Formatter) widths(rows [][]string) []int {
+	widths := []int{}
+	for _, row := range rows {
+		for x, cell := range row {
+			if x == len(widths) {
+				widths = append(widths, f.Width)
+			}
+			if len(cell) > widths[x] {
+				widths[x] = len(cell)
+			}
+		}
+	}
+
+	return widths
+}
+
+// pad aligns the labels of the rows to the left and the values to the right
+func (f Formatter) pad(cell string, x, width int) string {
+	if x == 0 && f.RowLabels != nil {
+		return fmt.Sprintf("%-*s", width, cell)
+	}
+
+	return fmt.Sprintf("%*s", width, cell)
+}
+
+func (f Formatter) plain(rows [][]string) string {
+	widths := f.widths(rows)
+
+	s := ""
+	for _, row := range rows {
+		for x, cell := range row {
+			s += f.pad(cell, x, widths[x]) + " "
+		}
+		s += "\n"
+	}
+	return s
+}
+
+func (f Formatter) markdown(rows [][]string) string {
+	widths := f.widths(rows)
+	// the separator needs at least 3 dashes
+	for x := range widths {
+		if widths[x] < 3 {
+			widths[x] = 3
+		}
+	}
+
+	line := func(row []string) string {
+		s := "|"
+		for x, cell := range row {
+			s += " " + f.pad(cell, x, widths[x]) + " |"
+		}
+		return s + "\n"
+	}
+
+	s := ""
+	for y, row := range rows {
+		s += line(row)
+		if y == 0 {
+			separator := make([]string, len(row))
+			for x := range separator {
+				separator[x] = strings.Repeat("-", widths[x]-1) + ":"
+				if x == 0 && f.RowLabels != nil {
+					separator[x] = strings.Repeat("-", widths[x])
+				}
+			}
+			s += line(separator)
+		}
+	}
+	return s
+}
+
+func (f Formatter) latex(rows [][]string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	widths := f.widths(rows)
+	columns := strings.Repeat("r", len(widths))
+	if f.RowLabels != nil {
+		columns = "l" + columns[1:]
+	}
+
+	s := "\\begin{tabular}{" + columns + "}\n"
+	for y, row := range rows {
+		cells := make([]string, len(row))
+		for x, cell := range row {
+			cells[x] = f.pad(cell, x, widths[x])
+		}
+		s += strings.Join(cells, " & ") + " \\\\\n"
+
+		if y == 0 && f.ColumnLabels != nil {
+			s += "\\hline\n"
+		}
+	}
+	return s + "\\end{tabular}\n"
+}
@@ -0,0 +1,781 @@
+package matrix
+
+import "math"
+
+// VectorOf is Vector with the elements of any Number type
+type VectorOf[T Number[T]] []T
+
+// MatrixOf is Matrix with the elements of any Number type, it's indexed [y][x] too.
+// The elimination, Det, Inverse, Solve and the simplex steps (PivotRowOf, OriginalBaseVectorOf)
+// are written once for MatrixOf, Matrix runs them as MatrixOf[Float64]
+type MatrixOf[T Number[T]] []VectorOf[T]
+
+// ShellVOf generates the zero vector
+func ShellVOf[T Number[T]](length int) VectorOf[T] {
+	return make(VectorOf[T], length)
+}
+
+// ShellMOf generates the zero matrix
+func ShellMOf[T Number[T]](width, height int) MatrixOf[T] {
+	m := make(MatrixOf[T], height)
+	for y := range m {
+		m[y] = ShellVOf[T](width)
+	}
+
+	return m
+}
+
+// IdentityOf generates the identity matrix of provided size
+func IdentityOf[T Number[T]](size int) MatrixOf[T] {
+	m := ShellMOf[T](size, size)
+	for i := range m {
+		m[i][i] = m[i][i].One()
+	}
+
+	return m
+}
+
+// VectorFrom converts the float vector to the number type
+func VectorFrom[T Number[T]](v Vector) VectorOf[T] {
+	vr := ShellVOf[T](len(v))
+	for i, value := range v {
+		vr[i] = vr[i].FromFloat64(value)
+	}
+
+	return vr
+}
+
+// MatrixFrom converts the float matrix to the number type
+func MatrixFrom[T Number[T]](m Matrix) MatrixOf[T] {
+	mr := make(MatrixOf[T], len(m))
+	for y, row := range m {
+		mr[y] = VectorFrom[T](row)
+	}
+
+	return mr
+}
+
+// Clone returns the copy of the vector
+func (v VectorOf[T]) Clone() VectorOf[T] {
+	return append(VectorOf[T]{}, v...)
+}
+
+// Sum calculates the sum of the elements
+func (v VectorOf[T]) Sum() T {
+	var acc T
+	for _, el := range v {
+		acc = acc.Add(el)
+	}
+
+	return acc
+}
+
+// MultiplyWithNumber multiplies every element by value
+func (v VectorOf[T]) MultiplyWithNumber(value T) VectorOf[T] {
+	vr := ShellVOf[T](len(v))
+	for i, el := range v {
+		vr[i] = el.Mul(value)
+	}
+
+	return vr
+}
+
+// MultiplyElementByElement multiplies two vectors element by element
+func (v VectorOf[T]) MultiplyElementByElement(v2 VectorOf[T]) VectorOf[T] {
+	vr := ShellVOf[T](len(v))
+	for i, el := range v {
+		vr[i] = el.Mul(v2[i])
+	}
+
+	return vr
+}
+
+// FillWith returns the copy of the vector with the first elements replaced by v2
+func (v VectorOf[T]) FillWith(v2 VectorOf[T]) VectorOf[T] {
+	vr := v.Clone()
+	copy(vr, v2)
+
+	return vr
+}
+
+// FindIndex finds the index of the value, the elements are compared within the tolerance of the type
+func (v VectorOf[T]) FindIndex(value T) int {
+	for i, el := range v {
+		if el.Sub(value).IsZero() {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// IsBaseVector checks if the vector has the only 1 and zeros elsewhere
+func (v VectorOf[T]) IsBaseVector() bool {
+	ones := 0
+	for _, el := range v {
+		switch {
+		case el.Sub(el.One()).IsZero():
+			ones++
+		case !el.IsZero():
+			return false
+		}
+	}
+
+	return ones == 1
+}
+
+// MaxOf returns the biggest element of the matrix
+func MaxOf[T Real[T]](m MatrixOf[T]) T {
+	max := m[0][0]
+	for _, row := range m {
+		for _, el := range row {
+			if el.Sub(max).Sign() > 0 {
+				max = el
+			}
+		}
+	}
+
+	return max
+}
+
+// MinOf returns the smallest element of the matrix
+func MinOf[T Real[T]](m MatrixOf[T]) T {
+	min := m[0][0]
+	for _, row := range m {
+		for _, el := range row {
+			if el.Sub(min).Sign() < 0 {
+				min = el
+			}
+		}
+	}
+
+	return min
+}
+
+// String converts the vector to string
+func (v VectorOf[T]) String() string {
+	s := ""
+	for _, element := range v {
+		s += element.String() + " "
+	}
+	return s
+}
+
+// Width returns count of columns
+func (m MatrixOf[T]) Width() int {
+	return len(m[0])
+}
+
+// Height returns count of rows
+func (m MatrixOf[T]) Height() int {
+	return len(m)
+}
+
+// Size returns width and height
+func (m MatrixOf[T]) Size() (int, int) {
+	return m.Width(), m.Height()
+}
+
+// Validate checks that the matrix is not empty and every row has the same length
+func (m MatrixOf[T]) Validate() error {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return ErrEmpty
+	}
+
+	for _, row := range m[1:] {
+		if len(row) != len(m[0]) {
+			return ErrDimensionMismatch
+		}
+	}
+
+	return nil
+}
+
+// Clone returns the copy of the matrix
+func (m MatrixOf[T]) Clone() MatrixOf[T] {
+	mr := make(MatrixOf[T], len(m))
+	for y, row := range m {
+		mr[y] = row.Clone()
+	}
+
+	return mr
+}
+
+// FillWith returns the copy of the matrix with the top left corner replaced by m2
+func (m MatrixOf[T]) FillWith(m2 MatrixOf[T]) MatrixOf[T] {
+	mr := m.Clone()
+	for y, row := range m2 {
+		copy(mr[y], row)
+	}
+
+	return mr
+}
+
+// Transpose transposes the matrix
+func (m MatrixOf[T]) Transpose() MatrixOf[T] {
+	w, h := m.Size()
+	mr := ShellMOf[T](h, w)
+	for y, row := range m {
+		for x, el := range row {
+			mr[x][y] = el
+		}
+	}
+
+	return mr
+}
+
+// GetColumn returns column vector at index i
+func (m MatrixOf[T]) GetColumn(i int) VectorOf[T] {
+	v := ShellVOf[T](m.Height())
+	for y, row := range m {
+		v[y] = row[i]
+	}
+
+	return v
+}
+
+// GetLastColumn returns last column
+func (m MatrixOf[T]) GetLastColumn() VectorOf[T] {
+	return m.GetColumn(m.Width() - 1)
+}
+
+// AddOf adds the matrices of the same size
+func AddOf[T Number[T]](m1, m2 MatrixOf[T]) (MatrixOf[T], error) {
+	if err := validateSameSizeOf(m1, m2); err != nil {
+		return nil, err
+	}
+
+	mr := m1.Clone()
+	for y, row := range m2 {
+		for x, el := range row {
+			mr[y][x] = mr[y][x].Add(el)
+		}
+	}
+
+	return mr, nil
+}
+
+// SubstractOf substracts m2 from m1 of the same size
+func SubstractOf[T Number[T]](m1, m2 MatrixOf[T]) (MatrixOf[T], error) {
+	if err := validateSameSizeOf(m1, m2); err != nil {
+		return nil, err
+	}
+
+	mr := m1.Clone()
+	for y, row := range m2 {
+		for x, el := range row {
+			mr[y][x] = mr[y][x].Sub(el)
+		}
+	}
+
+	return mr, nil
+}
+
+// validateSameSizeOf checks that both matrices are valid and have the same size
+func validateSameSizeOf[T Number[T]](m1, m2 MatrixOf[T]) error {
+	if err := m1.Validate(); err != nil {
+		return err
+	}
+
+	if err := m2.Validate(); err != nil {
+		return err
+	}
+
+	if w1, h1 := m1.Size(); w1 != m2.Width() || h1 != m2.Height() {
+		return ErrDimensionMismatch
+	}
+
+	return nil
+}
+
+// MultiplyOf multiplies the matrices, width of m1 must equal height of m2
+func MultiplyOf[T Number[T]](m1, m2 MatrixOf[T]) (MatrixOf[T], error) {
+	if err := m1.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := m2.Validate(); err != nil {
+		return nil, err
+	}
+
+	if m1.Width() != m2.Height() {
+		return nil, ErrDimensionMismatch
+	}
+
+	mr := ShellMOf[T](m2.Width(), m1.Height())
+	m2Columns := m2.Transpose()
+	for y, row := range m1 {
+		for x, column := range m2Columns {
+			mr[y][x] = row.MultiplyElementByElement(column).Sum()
+		}
+	}
+
+	return mr, nil
+}
+
+// MultiplyWithNumber multiplies every element by value
+func (m MatrixOf[T]) MultiplyWithNumber(value T) MatrixOf[T] {
+	mr := make(MatrixOf[T], len(m))
+	for y, row := range m {
+		mr[y] = row.MultiplyWithNumber(value)
+	}
+
+	return mr
+}
+
+// DivideRow divides each row's element by value
+func (m MatrixOf[T]) DivideRow(rowIndex int, value T) MatrixOf[T] {
+	mr := m.Clone()
+	for x, el := range mr[rowIndex] {
+		mr[rowIndex][x] = el.Div(value)
+	}
+
+	return mr
+}
+
+// SubstractRow substracts the row multiplied by multiplier from the other row
+func (m MatrixOf[T]) SubstractRow(rowIndexWhich int, rowIndexFrom int, multiplier T) MatrixOf[T] {
+	mr := m.Clone()
+	for x, el := range mr[rowIndexWhich] {
+		mr[rowIndexFrom][x] = mr[rowIndexFrom][x].Sub(el.Mul(multiplier))
+	}
+
+	return mr
+}
+
+// BaseVector creates a base vector at provided column with 1 at provided row
+func (m MatrixOf[T]) BaseVector(rowIndex, columnIndex int) MatrixOf[T] {
+	pivotRow := m[rowIndex].Clone()
+	for x, el := range pivotRow {
+		pivotRow[x] = el.Div(m[rowIndex][columnIndex])
+	}
+
+	mr := make(MatrixOf[T], len(m))
+	for y, row := range m {
+		if y == rowIndex {
+			mr[y] = pivotRow
+			continue
+		}
+
+		multiplier := row[columnIndex]
+		mr[y] = ShellVOf[T](len(row))
+		for x, el := range row {
+			mr[y][x] = el.Sub(pivotRow[x].Mul(multiplier))
+		}
+	}
+
+	return mr
+}
+
+// GetBasis returns the values of the variables: b of the row for every base column and zero for the others
+func (m MatrixOf[T]) GetBasis() VectorOf[T] {
+	basis := ShellVOf[T](m.Width())
+	b := m.GetLastColumn()
+
+	var one T
+	one = one.One()
+	for x, column := range m.Transpose() {
+		if column.IsBaseVector() {
+			basis[x] = b[column.FindIndex(one)]
+		}
+	}
+
+	return basis
+}
+
+// isPositive checks if the number is above the tolerance, exact numbers are compared with zero exactly
+func isPositive[T Real[T]](value T, tolerance float64) bool {
+	return value.Sign() > 0 && !isZero(value, tolerance)
+}
+
+// PivotRowOf returns the row leaving the basis when the column enters it, it's Matrix.PivotRow for any
+// Real numbers. The ratios b / a are compared exactly when the tolerance is 0
+func PivotRowOf[T Real[T]](m MatrixOf[T], columnIndex int, basis []int, tolerance float64) int {
+	last := m.Width() - 1
+
+	pivotRowIndex := -1
+	var best T
+	for y := range basis {
+		a := m[y][columnIndex]
+		if !isPositive(a, tolerance) {
+			continue
+		}
+
+		ratio := m[y][last].Div(a)
+		if pivotRowIndex == -1 {
+			pivotRowIndex, best = y, ratio
+			continue
+		}
+
+		if isPositive(best.Sub(ratio), tolerance) || (!isPositive(ratio.Sub(best), tolerance) && basis[y] < basis[pivotRowIndex]) {
+			pivotRowIndex, best = y, ratio
+		}
+	}
+
+	return pivotRowIndex
+}
+
+// OriginalBaseVectorOf returns original base vector: every row gets a base column and every b is non-negative.
+// It's Matrix.OriginalBaseVector for any Real numbers, ErrInfeasible is returned when the auxiliary
+// variable x0 can't be driven to zero
+func OriginalBaseVectorOf[T Real[T]](m MatrixOf[T]) (MatrixOf[T], error) {
+	w := m.Width()
+
+	mr := m.Gauss()
+
+	var zero T
+	minBIndex := -1
+	for y, b := range mr.GetLastColumn() {
+		if isPositive(zero.Sub(b), zero.Tolerance()) && (minBIndex == -1 || b.Sub(mr[minBIndex][w-1]).Sign() < 0) {
+			minBIndex = y
+		}
+	}
+
+	if minBIndex == -1 {
+		return mr, nil
+	}
+
+	// x0 is placed before b, so the columns of the variables keep their indexes
+	x0 := w - 1
+	aux := ShellMOf[T](w+1, mr.Height())
+	for y, row := range mr {
+		copy(aux[y], row[:x0])
+		aux[y][x0] = zero.FromFloat64(-1)
+		aux[y][w] = row[x0]
+	}
+
+	// x0 is -1 in the basis, so it leaves first on ties,
+	// the rows without base column go after every variable
+	basis := make([]int, aux.Height())
+	for y := range basis {
+		basis[y] = w + y
+	}
+	for x, column := range aux.Transpose()[:x0] {
+		if column.IsBaseVector() {
+			basis[column.FindIndex(zero.One())] = x
+		}
+	}
+
+	row := minBIndex
+	aux = aux.BaseVector(row, x0)
+	basis[row] = -1
+	tolerance := aux.Tolerance()
+
+	for basis[row] == -1 && isPositive(aux[row][w], tolerance) {
+		// x0 = b - a * x decreases when the column with positive a enters
+		pivotColumnIndex := -1
+		for x, a := range aux[row][:x0] {
+			if isPositive(a, tolerance) {
+				pivotColumnIndex = x
+				break
+			}
+		}
+
+		if pivotColumnIndex == -1 {
+			return nil, ErrInfeasible
+		}
+
+		pivotRowIndex := PivotRowOf(aux, pivotColumnIndex, basis, tolerance)
+		aux = aux.BaseVector(pivotRowIndex, pivotColumnIndex)
+		basis[pivotRowIndex] = pivotColumnIndex
+	}
+
+	// x0 left at zero level is replaced by any variable of its row
+	if basis[row] == -1 {
+		for x, a := range aux[row][:x0] {
+			if !isZero(a, tolerance) {
+				aux = aux.BaseVector(row, x)
+				break
+			}
+		}
+	}
+
+	mr = ShellMOf[T](w, aux.Height())
+	for y, r := range aux {
+		copy(mr[y], r[:x0])
+		mr[y][x0] = r[w]
+	}
+
+	return mr, nil
+}
+
+// Gauss makes gauss transform with the matrix taking the first non-zero element of every row
+func (m MatrixOf[T]) Gauss() MatrixOf[T] {
+	mr := m
+	usedColumns := make([]bool, m.Width())
+	for y := range m {
+		for x := range m[y] {
+			if !usedColumns[x] && !mr[y][x].IsZero() {
+				mr = mr.BaseVector(y, x)
+				usedColumns[x] = true
+				break
+			}
+		}
+	}
+
+	return mr
+}
+
+// Tolerance returns the tolerance of the element type relative to the biggest element of the matrix,
+// it's 0 for exact types
+func (m MatrixOf[T]) Tolerance() float64 {
+	var zero T
+	max := 0.0
+	for _, row := range m {
+		for _, value := range row {
+			max = math.Max(max, value.Abs())
+		}
+	}
+
+	return zero.Tolerance() * math.Max(max, 1)
+}
+
+// isZero checks if the number is zero within the tolerance, exact numbers are compared with zero exactly
+func isZero[T Number[T]](value T, tolerance float64) bool {
+	return value.IsZero() || (tolerance > 0 && value.Abs() <= tolerance)
+}
+
+// findPivot looks for the pivot in the rows from the provided one and the unused first columns.
+// It returns -1 column when every remaining element is zero within the tolerance
+func (m MatrixOf[T]) findPivot(from, columns int, used []bool, pivoting Pivoting, tolerance float64) (int, int) {
+	pivotRow, pivotColumn := -1, -1
+	for x := 0; x < columns; x++ {
+		if used[x] {
+			continue
+		}
+
+		for y := from; y < m.Height(); y++ {
+			if !isZero(m[y][x], tolerance) && (pivotColumn == -1 || m[y][x].Abs() > m[pivotRow][pivotColumn].Abs()) {
+				pivotRow, pivotColumn = y, x
+			}
+		}
+
+		if pivoting == PivotingPartial && pivotColumn != -1 {
+			break
+		}
+	}
+
+	return pivotRow, pivotColumn
+}
+
+// eliminate makes the reduced row echelon form of the first columns of the matrix.
+// It returns the pivot column of every row and the absolute values of the pivots
+func (m MatrixOf[T]) eliminate(columns int, pivoting Pivoting) (MatrixOf[T], []int, []float64) {
+	mr := m.Clone()
+	tolerance := mr.Tolerance()
+
+	pivots := []int{}
+	pivotValues := []float64{}
+	used := make([]bool, columns)
+
+	for y := 0; y < mr.Height(); y++ {
+		pivot, x := mr.findPivot(y, columns, used, pivoting, tolerance)
+		if x == -1 {
+			break
+		}
+
+		mr[y], mr[pivot] = mr[pivot], mr[y]
+
+		pivotValues = append(pivotValues, mr[y][x].Abs())
+		mr = mr.BaseVector(y, x)
+		pivots = append(pivots, x)
+		used[x] = true
+	}
+
+	return mr, pivots, pivotValues
+}
+
+// isIllConditioned estimates the condition by the ratio of the biggest and the smallest pivots,
+// the matrices of exact numbers are never ill-conditioned
+func (m MatrixOf[T]) isIllConditioned(pivotValues []float64) bool {
+	var zero T
+	if zero.Tolerance() == 0 || len(pivotValues) == 0 {
+		return false
+	}
+
+	min, max := math.Inf(1), 0.0
+	for _, value := range pivotValues {
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+
+	return max/min > illConditioned
+}
+
+// GaussWithPivoting makes gauss transform with the chosen pivoting. The last column is the right side
+// of the system and never becomes a pivot one, the columns are not reordered by complete pivoting.
+// PivotingNone is the same as Gauss
+func (m MatrixOf[T]) GaussWithPivoting(pivoting Pivoting) MatrixOf[T] {
+	if pivoting == PivotingNone {
+		return m.Gauss()
+	}
+
+	if m.Validate() != nil {
+		return m.Clone()
+	}
+
+	mr, _, _ := m.eliminate(m.Width()-1, pivoting)
+	return mr
+}
+
+// RREF makes the reduced row echelon form with partial pivoting and returns it with the pivot columns
+func (m MatrixOf[T]) RREF() (MatrixOf[T], []int) {
+	if m.Validate() != nil {
+		return m.Clone(), []int{}
+	}
+
+	mr, pivots, _ := m.eliminate(m.Width(), PivotingPartial)
+	return mr, pivots
+}
+
+// Rank calculates the rank of the matrix, values within the tolerance of the type are treated as zeros
+func (m MatrixOf[T]) Rank() int {
+	if m.Validate() != nil {
+		return 0
+	}
+
+	_, pivots, _ := m.eliminate(m.Width(), PivotingPartial)
+	return len(pivots)
+}
+
+// Det calculates the determinant with Gaussian elimination and partial pivoting.
+// 0 means a pivot is zero within the tolerance of the type, so the matrix is singular.
+// ErrIllConditioned is returned along with the determinant when the matrix is close to singular
+func (m MatrixOf[T]) Det() (T, error) {
+	var det T
+	if err := m.Validate(); err != nil {
+		return det, err
+	}
+
+	n := m.Height()
+	if m.Width() != n {
+		return det, ErrDimensionMismatch
+	}
+
+	mr := m.Clone()
+	tolerance := mr.Tolerance()
+	pivotValues := []float64{}
+	det = det.One()
+	for x := 0; x < n; x++ {
+		pivot := x
+		for y := x + 1; y < n; y++ {
+			if mr[y][x].Abs() > mr[pivot][x].Abs() {
+				pivot = y
+			}
+		}
+
+		if isZero(mr[pivot][x], tolerance) {
+			var zero T
+			return zero, nil
+		}
+
+		if pivot != x {
+			mr[x], mr[pivot] = mr[pivot], mr[x]
+			det = det.Mul(det.FromFloat64(-1))
+		}
+
+		pivotValues = append(pivotValues, mr[x][x].Abs())
+		det = det.Mul(mr[x][x])
+		for y := x + 1; y < n; y++ {
+			multiplier := mr[y][x].Div(mr[x][x])
+			for k := x; k < n; k++ {
+				mr[y][k] = mr[y][k].Sub(mr[x][k].Mul(multiplier))
+			}
+		}
+	}
+
+	if m.isIllConditioned(pivotValues) {
+		return det, ErrIllConditioned
+	}
+
+	return det, nil
+}
+
+// Inverse calculates the inverse matrix with Gauss-Jordan elimination and partial pivoting.
+// ErrIllConditioned is returned along with the inverse when the matrix is close to singular
+func (m MatrixOf[T]) Inverse() (MatrixOf[T], error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	n := m.Height()
+	if m.Width() != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	augmented := make(MatrixOf[T], n)
+	identity := IdentityOf[T](n)
+	for y, row := range m {
+		augmented[y] = append(row.Clone(), identity[y]...)
+	}
+
+	mr, pivots, pivotValues := augmented.eliminate(n, PivotingPartial)
+	if len(pivots) < n {
+		return nil, ErrSingular
+	}
+
+	inverse := make(MatrixOf[T], n)
+	for y, row := range mr {
+		inverse[y] = row[n:].Clone()
+	}
+
+	if m.isIllConditioned(pivotValues) {
+		return inverse, ErrIllConditioned
+	}
+
+	return inverse, nil
+}
+
+// Solve solves the system m * x = b with Gaussian elimination and partial pivoting.
+// Rectangular systems get a particular solution with free variables set to 0;
+// ErrSingular is returned for square systems without the only solution.
+// ErrIllConditioned is returned along with the solution when the matrix is close to singular
+func (m MatrixOf[T]) Solve(b VectorOf[T]) (VectorOf[T], error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	w, h := m.Size()
+	if len(b) != h {
+		return nil, ErrDimensionMismatch
+	}
+
+	augmented := make(MatrixOf[T], h)
+	for y, row := range m {
+		augmented[y] = append(row.Clone(), b[y])
+	}
+
+	mr, pivots, pivotValues := augmented.eliminate(w, PivotingPartial)
+
+	tolerance := augmented.Tolerance()
+	for y := len(pivots); y < h; y++ {
+		if !isZero(mr[y][w], tolerance) {
+			return nil, ErrInconsistent
+		}
+	}
+
+	if w == h && len(pivots) < w {
+		return nil, ErrSingular
+	}
+
+	x := ShellVOf[T](w)
+	for y, column := range pivots {
+		x[column] = mr[y][w]
+	}
+
+	if m.isIllConditioned(pivotValues) {
+		return x, ErrIllConditioned
+	}
+
+	return x, nil
+}
+
+// String converts the matrix to string
+func (m MatrixOf[T]) String() string {
+	s := ""
+	for _, row := range m {
+		s += row.String()
+		s += "\n"
+	}
+	return s
+}
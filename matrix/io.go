@@ -0,0 +1,346 @@
+package matrix
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrFormat is returned when the input can't be parsed as the matrix
+var ErrFormat = errors.New("matrix: invalid format")
+
+// maxReadElements limits the size of the matrix ReadMatrixMarket allocates for the sizes from the header
+const maxReadElements = 1 << 24
+
+// MatrixMarketFormat is the way Matrix Market file stores the values
+type MatrixMarketFormat int
+
+const (
+	// MatrixMarketArray stores every value column by column
+	MatrixMarketArray MatrixMarketFormat = iota
+	// MatrixMarketCoordinate stores the non-zero values with their 1-based indexes
+	MatrixMarketCoordinate
+)
+
+// formatValue formats the float so it's read back exactly
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// parseValues parses the row of the floats
+func parseValues(fields []string) (Vector, error) {
+	v := ShellV(len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, ErrFormat
+		}
+		v[i] = value
+	}
+
+	return v, nil
+}
+
+// parseInts parses the row of the integers
+func parseInts(fields []string) ([]int, error) {
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, ErrFormat
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// ReadCSV reads the matrix from CSV, the first record is returned as the column names when header is true
+func ReadCSV(r io.Reader, header bool) (Matrix, []string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	// the errors of the reader are returned as they are, only the malformed CSV is ErrFormat
+	records, err := reader.ReadAll()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, nil, ErrFormat
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var names []string
+	if header && len(records) > 0 {
+		names = records[0]
+		records = records[1:]
+	}
+
+	m := make(Matrix, len(records))
+	for y, record := range records {
+		if m[y], err = parseValues(record); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if names != nil && len(names) != m.Width() {
+		return nil, nil, ErrDimensionMismatch
+	}
+
+	return m, names, nil
+}
+
+// WriteCSV writes the matrix as CSV, the header is written when it isn't nil
+func WriteCSV(w io.Writer, m Matrix, header []string) error {
+	if header != nil && len(header) != m.Width() {
+		return ErrDimensionMismatch
+	}
+
+	writer := csv.NewWriter(w)
+	if header != nil {
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range m {
+		record := make([]string, len(row))
+		for x, value := range row {
+			record[x] = formatValue(value)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadJSON reads the matrix from JSON array of rows
+func ReadJSON(r io.Reader) (Matrix, error) {
+	var m Matrix
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, ErrFormat
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// WriteJSON writes the matrix as JSON array of rows
+func WriteJSON(w io.Writer, m Matrix) error {
+	return json.NewEncoder(w).Encode(m)
+}
+
+// ReadVectorJSON reads the vector from JSON array
+func ReadVectorJSON(r io.Reader) (Vector, error) {
+	var v Vector
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return nil, ErrFormat
+	}
+
+	return v, nil
+}
+
+// WriteVectorJSON writes the vector as JSON array
+func WriteVectorJSON(w io.Writer, v Vector) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// ReadMatrixMarket reads real, integer or pattern matrix of general, symmetric or skew-symmetric structure
+// in array or coordinate Matrix Market format
+func ReadMatrixMarket(r io.Reader) (Matrix, error) {
+	scanner := bufio.NewScanner(r)
+
+	if !scanner.Scan() {
+		return nil, ErrFormat
+	}
+
+	banner := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(banner) != 5 || banner[0] != "%%matrixmarket" || banner[1] != "matrix" {
+		return nil, ErrFormat
+	}
+
+	format, field, symmetry := banner[2], banner[3], banner[4]
+	if (format != "array" && format != "coordinate") ||
+		(field != "real" && field != "integer" && field != "pattern") ||
+		(symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric") ||
+		(field == "pattern" && format == "array") {
+		return nil, ErrFormat
+	}
+
+	// the data lines are the numbers separated by spaces, comments start with %
+	lines := [][]string{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		lines = append(lines, strings.Fields(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, ErrFormat
+	}
+
+	sizes, err := parseInts(lines[0])
+	if err != nil || (format == "array" && len(sizes) != 2) || (format == "coordinate" && len(sizes) != 3) {
+		return nil, ErrFormat
+	}
+
+	// the sizes and the count of the entries are checked before the matrix is allocated
+	height, width := sizes[0], sizes[1]
+	if height <= 0 || width <= 0 || height > maxReadElements/width || (symmetry != "general" && height != width) {
+		return nil, ErrFormat
+	}
+
+	entries := height * width
+	switch symmetry {
+	case "symmetric":
+		entries = height * (height + 1) / 2
+	case "skew-symmetric":
+		entries = height * (height - 1) / 2
+	}
+
+	if format == "coordinate" {
+		if sizes[2] < 0 || sizes[2] > entries {
+			return nil, ErrFormat
+		}
+		entries = sizes[2]
+	}
+
+	if len(lines)-1 != entries {
+		return nil, ErrFormat
+	}
+
+	m := ShellM(width, height)
+	set := func(y, x int, value float64) {
+		m[y][x] = value
+		switch symmetry {
+		case "symmetric":
+			m[x][y] = value
+		case "skew-symmetric":
+			m[x][y] = -value
+		}
+	}
+
+	if format == "array" {
+		// the values go column by column, only the lower triangle is stored for the symmetric matrices
+		positions := [][2]int{}
+		for x := 0; x < width; x++ {
+			from := 0
+			switch symmetry {
+			case "symmetric":
+				from = x
+			case "skew-symmetric":
+				from = x + 1
+			}
+
+			for y := from; y < height; y++ {
+				positions = append(positions, [2]int{y, x})
+			}
+		}
+
+		for i, line := range lines[1:] {
+			values, err := parseValues(line)
+			if err != nil || len(values) != 1 {
+				return nil, ErrFormat
+			}
+
+			set(positions[i][0], positions[i][1], values[0])
+		}
+
+		return m, nil
+	}
+
+	// every element is set once, the mirrored elements of the symmetric matrices too
+	seen := map[[2]int]bool{}
+	for _, line := range lines[1:] {
+		if (field == "pattern" && len(line) != 2) || (field != "pattern" && len(line) != 3) {
+			return nil, ErrFormat
+		}
+
+		indexes, err := parseInts(line[:2])
+		if err != nil {
+			return nil, ErrFormat
+		}
+
+		y, x := indexes[0]-1, indexes[1]-1
+		if y < 0 || y >= height || x < 0 || x >= width || seen[[2]int{y, x}] ||
+			(symmetry != "general" && seen[[2]int{x, y}]) || (symmetry == "skew-symmetric" && x == y) {
+			return nil, ErrFormat
+		}
+		seen[[2]int{y, x}] = true
+
+		value := 1.0
+		if field != "pattern" {
+			values, err := parseValues(line[2:])
+			if err != nil {
+				return nil, ErrFormat
+			}
+			value = values[0]
+		}
+		set(y, x, value)
+	}
+
+	return m, nil
+}
+
+// WriteMatrixMarket writes the general real matrix in Matrix Market format
+func WriteMatrixMarket(w io.Writer, m Matrix, format MatrixMarketFormat) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	width, height := m.Size()
+	writer := bufio.NewWriter(w)
+
+	if format == MatrixMarketCoordinate {
+		count := 0
+		for _, row := range m {
+			for _, value := range row {
+				if value != 0 {
+					count++
+				}
+			}
+		}
+
+		fmt.Fprintln(writer, "%%MatrixMarket matrix coordinate real general")
+		fmt.Fprintln(writer, height, width, count)
+		for y, row := range m {
+			for x, value := range row {
+				if value != 0 {
+					fmt.Fprintln(writer, y+1, x+1, formatValue(value))
+				}
+			}
+		}
+	} else {
+		fmt.Fprintln(writer, "%%MatrixMarket matrix array real general")
+		fmt.Fprintln(writer, height, width)
+		for x := 0; x < width; x++ {
+			for y := 0; y < height; y++ {
+				fmt.Fprintln(writer, formatValue(m[y][x]))
+			}
+		}
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,133 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// illConditioned is the pivots ratio the matrix is considered ill-conditioned from
+const illConditioned = 1e8
+
+var (
+	// ErrIllConditioned is returned along with the result when the matrix is close to singular
+	ErrIllConditioned = errors.New("matrix: matrix is ill-conditioned")
+	// ErrInconsistent is returned when the system has no solution
+	ErrInconsistent = errors.New("matrix: system is inconsistent")
+)
+
+// Identity generates the identity matrix of provided size
+func Identity(size int) Matrix {
+	m := ShellM(size, size)
+	for i := range m {
+		m[i][i] = 1
+	}
+
+	return m
+}
+
+// maxAbs returns the biggest absolute value of the matrix elements
+func (m Matrix) maxAbs() float64 {
+	max := 0.0
+	for _, row := range m {
+		for _, value := range row {
+			max = math.Max(max, math.Abs(value))
+		}
+	}
+
+	return max
+}
+
+// validateSquare checks that the matrix is valid and square
+func (m Matrix) validateSquare() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	if m.Width() != m.Height() {
+		return ErrDimensionMismatch
+	}
+
+	return nil
+}
+
+// Pivoting is the way the pivot element of Gauss elimination is chosen
+type Pivoting int
+
+const (
+	// PivotingNone takes the first non-zero element of the row
+	PivotingNone Pivoting = iota
+	// PivotingPartial takes the biggest by absolute value element of the column
+	PivotingPartial
+	// PivotingComplete takes the biggest by absolute value element of the remaining rows and columns
+	PivotingComplete
+)
+
+// toMatrix converts the matrix of Float64 back to Matrix
+func toMatrix(m MatrixOf[Float64]) Matrix {
+	if m == nil {
+		return nil
+	}
+
+	mr := make(Matrix, len(m))
+	for y, row := range m {
+		mr[y] = toVector(row)
+	}
+
+	return mr
+}
+
+// toVector converts the vector of Float64 back to Vector
+func toVector(v VectorOf[Float64]) Vector {
+	if v == nil {
+		return nil
+	}
+
+	vr := ShellV(len(v))
+	for i, value := range v {
+		vr[i] = float64(value)
+	}
+
+	return vr
+}
+
+// GaussWithPivoting makes gauss transform with the chosen pivoting. The last column is the right side
+// of the system and never becomes a pivot one, the columns are not reordered by complete pivoting.
+// PivotingNone is the same as Gauss
+func (m Matrix) GaussWithPivoting(pivoting Pivoting) Matrix {
+	return toMatrix(MatrixFrom[Float64](m).GaussWithPivoting(pivoting))
+}
+
+// Det calculates the determinant with Gaussian elimination and partial pivoting.
+// 0 means a pivot is zero within the tolerance, so the matrix is singular.
+// ErrIllConditioned is returned along with the determinant when the matrix is close to singular
+func (m Matrix) Det() (float64, error) {
+	det, err := MatrixFrom[Float64](m).Det()
+	return float64(det), err
+}
+
+// RREF makes the reduced row echelon form with partial pivoting and returns it with the pivot columns
+func (m Matrix) RREF() (Matrix, []int) {
+	mr, pivots := MatrixFrom[Float64](m).RREF()
+	return toMatrix(mr), pivots
+}
+
+// Rank calculates the rank of the matrix, values relatively smaller than Epsilon are treated as zeros
+func (m Matrix) Rank() int {
+	return MatrixFrom[Float64](m).Rank()
+}
+
+// Inverse calculates the inverse matrix with Gauss-Jordan elimination and partial pivoting.
+// ErrIllConditioned is returned along with the inverse when the matrix is close to singular
+func (m Matrix) Inverse() (Matrix, error) {
+	inverse, err := MatrixFrom[Float64](m).Inverse()
+	return toMatrix(inverse), err
+}
+
+// Solve solves the system m * x = b with Gaussian elimination and partial pivoting.
+// Rectangular systems get a particular solution with free variables set to 0;
+// ErrSingular is returned for square systems without the only solution.
+// ErrIllConditioned is returned along with the solution when the matrix is close to singular
+func (m Matrix) Solve(b Vector) (Vector, error) {
+	x, err := MatrixFrom[Float64](m).Solve(VectorFrom[Float64](b))
+	return toVector(x), err
+}
@@ -1,10 +1,13 @@
 package matrix
 
 import (
-	"fmt"
+	"errors"
 	"math"
 )
 
+// ErrInfeasible is returned when the system has no solution with non-negative variables
+var ErrInfeasible = errors.New("matrix: system has no non-negative solution")
+
 // Vector is just 1d array of float64
 type Vector []float64
 
@@ -53,7 +56,7 @@ func ShellM(width, height int) Matrix {
 // FindIndex finds the index of a value
 func (v Vector) FindIndex(value float64) int {
 	for i, val := range v {
-		if val == value {
+		if Equal(val, value) {
 			return i
 		}
 	}
@@ -105,19 +108,15 @@ func (v Vector) Sum() float64 {
 	return acc
 }
 
+// HumaniazeValue formats the value without the fractional part when it's close to integer
 func HumaniazeValue(value float64) string {
-	remain := value - math.Round(value)
-	if remain < 10e-3 {
-		return fmt.Sprintf("%.0f", value)
-	}
-
-	return fmt.Sprintf("%.3f", value)
+	return Formatter{Precision: 3, Humanize: true}.FormatValue(value)
 }
 
 func (v Vector) CountValue(value float64) int {
 	count := 0
 	for _, el := range v {
-		if el == value {
+		if Equal(el, value) {
 			count++
 		}
 	}
@@ -187,7 +186,7 @@ func Substract(m1, m2 Matrix) Matrix {
 	for y := range m {
 		m[y] = make(Vector, w)
 
-		for x := 0; x < h; x++ {
+		for x := 0; x < w; x++ {
 			m[y][x] = m1[y][x] - m2[y][x]
 		}
 	}
@@ -361,54 +360,33 @@ func minInt(i1, i2 int) int {
 	return i2
 }
 
-// String converts Matrix to string
+// String converts Matrix to string with DefaultFormatter
 func (m Matrix) String() string {
-	s := ""
-	for _, row := range m {
-		s += row.String()
-		s += "\n"
-	}
-	return s
+	return DefaultFormatter.FormatMatrix(m)
 }
 
-// String converts Vector to string
+// String converts Vector to string with DefaultFormatter
 func (v Vector) String() string {
-	s := ""
-	for _, element := range v {
-		s += fmt.Sprintf("%6.3f", element) + " "
-	}
-	return s
+	return DefaultFormatter.FormatVector(v)
 }
 
-// BaseVector creates a base vector at provided column with 1 at provided row
+// BaseVector creates a base vector at provided column with 1 at provided row.
+// The rows are processed in the calling goroutine, BaseVectorParallel splits them among the workers
 func (m Matrix) BaseVector(rowIndex, columnIndex int) Matrix {
-	mr := m.DivideRow(rowIndex, m[rowIndex][columnIndex])
-	for y, row := range m {
-		if y != rowIndex {
-			mr = mr.SubstractRow(rowIndex, y, row[columnIndex])
-		}
-	}
+	return m.BaseVectorParallel(rowIndex, columnIndex, 1)
+}
 
-	return mr
+// PivotRow returns the row leaving the basis when the column enters it. b is the last column, the row
+// with the minimal ratio b / a among the elements a above tolerance leaves and the ties go to the smallest
+// basis variable. With the first improving column entering it's Bland's rule, so the simplex method
+// doesn't cycle. Only the rows of the basis are compared, -1 is returned when there's no such element
+func (m Matrix) PivotRow(columnIndex int, basis []int, tolerance float64) int {
+	return PivotRowOf(MatrixFrom[Float64](m), columnIndex, basis, tolerance)
 }
 
-// Gauss makes gauss transform with the Matrix
+// Gauss makes gauss transform with the Matrix taking the first non-zero element of every row
 func (m Matrix) Gauss() Matrix {
-	width, height := m.Size()
-
-	mr := m
-	usedColumns := ShellV(width)
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			if usedColumns[x] == 0 && mr[y][x] != 0 {
-				mr = mr.BaseVector(y, x)
-				usedColumns[x] = 1
-				break
-			}
-		}
-	}
-
-	return mr
+	return toMatrix(MatrixFrom[Float64](m).Gauss())
 }
 
 // GetColumn returns column vector at index i
@@ -432,90 +410,17 @@ func (m Matrix) GetLastColumn() Vector {
 	return v
 }
 
-// OriginalBaseVector returns original base vector
-func (m Matrix) OriginalBaseVector() Matrix {
-	w := m.Width()
-
-	println("start")
-	println(m.String())
-
-	mr := m.Gauss()
-	println("after gauss")
-	println(mr.String())
-
-	i := 0
-	for {
-		B := mr.GetLastColumn()
-
-		everyIsPositive := true
-		for _, b := range B {
-			everyIsPositive = everyIsPositive && b > 0
-		}
-
-		if everyIsPositive {
-			println("success")
-			break
-		}
-		println("still not every b > 0")
-
-		minBIndex := -1
-		minBValue := 0.0
-		for i, b := range B {
-			if b <= 0 && minBValue > b {
-				minBValue = b
-				minBIndex = i
-			}
-		}
-
-		fmt.Printf("working with row %d\n", minBIndex)
-
-		for y, row := range mr {
-			if row[w-1] < 0 && y != minBIndex {
-				mr = mr.SubstractRow(minBIndex, y, 1)
-			}
-		}
-
-		println("after substrtact")
-		println(mr.String())
-
-		mr = MultiplyRow(mr, minBIndex, -1)
-
-		println("after mutliply -1")
-		println(mr.String())
-
-		pivotColumnIndex := -1
-		for x, a := range mr[minBIndex] {
-			if a > 0 && x < w-1 {
-				pivotColumnIndex = x
-				break
-			}
-		}
-
-		pivotRowIndex := -1
-		pivotValue := math.MaxFloat64
-		for y, row := range mr {
-			val := row[w-1] / row[pivotColumnIndex]
-
-			if val >= 0 && val < pivotValue && (row[pivotColumnIndex] <= 0 || y == minBIndex) {
-				pivotValue = val
-				pivotRowIndex = y
-			}
-		}
-
-		i++
-
-		// fmt.Printf()
-		// println("pre-baseVector")
-		// println(mr.String())
-
-		fmt.Printf("pivot at x: %d, y: %d\n", pivotColumnIndex, pivotRowIndex)
-		mr = mr.BaseVector(pivotRowIndex, pivotColumnIndex)
-
-		println("after base vector")
-		println(mr.String())
+// OriginalBaseVector returns original base vector: every row gets a base column and every b is non-negative.
+// When Gauss transform leaves negative b, the auxiliary variable x0 with -1 in every row is added,
+// it enters the basis at the row with the minimal b and then is minimized with PivotRow.
+// ErrInfeasible is returned when x0 can't be driven to zero
+func (m Matrix) OriginalBaseVector() (Matrix, error) {
+	mr, err := OriginalBaseVectorOf(MatrixFrom[Float64](m))
+	if err != nil {
+		return nil, err
 	}
 
-	return mr
+	return toMatrix(mr), nil
 }
 
 // SetValue sets a value at an index
@@ -524,19 +429,7 @@ func (v Vector) SetValue(index int, value float64) Vector {
 	return v
 }
 
+// GetBasis returns the values of the variables: b of the row for every base column and zero for the others
 func (m Matrix) GetBasis() Vector {
-	columns := m.Transpose()
-	basis := ShellV(len(columns))
-
-	B := m.GetLastColumn()
-
-	for x, column := range columns {
-		isBase := column.IsBaseVector()
-		if isBase {
-			bIndex := column.FindIndex(1)
-			basis[x] = B[bIndex]
-		}
-	}
-
-	return basis
+	return toVector(MatrixFrom[Float64](m).GetBasis())
 }
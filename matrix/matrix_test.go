@@ -1,8 +1,15 @@
 package matrix
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func Test_shellV(t *testing.T) {
@@ -145,7 +152,7 @@ func Test_sumV(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SumV(tt.args.v); got != tt.want {
+			if got := tt.args.v.Sum(); got != tt.want {
 				t.Errorf("sumV() = %v, want %v", got, tt.want)
 			}
 		})
@@ -184,6 +191,7 @@ func Test_substract(t *testing.T) {
 		want Matrix
 	}{
 		{"test1", args{Matrix{{1, 2, 3}, {4, 5, 6}}, Matrix{{1, 2, 3}, {4, 5, 6}}}, Matrix{{0, 0, 0}, {0, 0, 0}}},
+		{"test2", args{Matrix{{1, 2, 3}, {4, 5, 6}}, Matrix{{0, 0, 1}, {0, 0, 1}}}, Matrix{{1, 2, 2}, {4, 5, 5}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -229,7 +237,7 @@ func Test_multiplyElementByElement(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := MultiplyElementByElement(tt.args.v1, tt.args.v2); !reflect.DeepEqual(got, tt.want) {
+			if got := tt.args.v1.MultiplyElementByElement(tt.args.v2); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("multiplyElementByElement() = %v, want %v", got, tt.want)
 			}
 		})
@@ -299,3 +307,1107 @@ func TestGauss(t *testing.T) {
 		})
 	}
 }
+
+func TestPivotRow(t *testing.T) {
+	m := Matrix{
+		{1, 1, 0, 0, 2},
+		{2, 0, 1, 0, 4},
+		{-1, 0, 0, 1, 1},
+	}
+
+	tests := []struct {
+		name   string
+		column int
+		basis  []int
+		want   int
+	}{
+		{"minimal ratio", 1, []int{1, 2, 3}, 0},
+		{"ties go to the smallest basis variable", 0, []int{3, 2, 1}, 1},
+		{"only the basis rows", 0, []int{3, 2}, 1},
+		{"no positive element", 3, []int{1, 2}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.PivotRow(tt.column, tt.basis, Epsilon); got != tt.want {
+				t.Errorf("PivotRow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOriginalBaseVector checks that the rows with zero right-hand side are accepted as feasible,
+// the degenerate basis is valid and there's no row to repair. The system without non-negative
+// solutions returns ErrInfeasible
+func TestOriginalBaseVector(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix
+		err  error
+	}{
+		{"zero b", Matrix{
+			{1, 1, 1, 0, 0},
+			{1, -1, 0, 1, 0},
+		}, nil},
+		{"zero and positive b", Matrix{
+			{1, 2, 1, 0, 4},
+			{2, -1, 0, 1, 0},
+		}, nil},
+		{"negative b", Matrix{
+			{1, 1, -1, 0, 2},
+			{-1, 1, 0, 1, -1},
+		}, nil},
+		{"infeasible", Matrix{
+			{1, 1, 1, -1},
+		}, ErrInfeasible},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.OriginalBaseVector()
+			if err != tt.err {
+				t.Fatalf("OriginalBaseVector() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+
+			for y, b := range got.GetLastColumn() {
+				if b < -Epsilon {
+					t.Errorf("OriginalBaseVector() row %d has b = %v, want b >= 0", y, b)
+				}
+			}
+
+			x := got.GetBasis()
+			for y, row := range tt.m {
+				w := len(row) - 1
+				if left := row[:w].MultiplyElementByElement(x[:w]).Sum(); !Equal(left, row[w]) {
+					t.Errorf("OriginalBaseVector() basis %v gives %v in row %d, want %v", x, left, y, row[w])
+				}
+			}
+		})
+	}
+}
+
+func TestAddE(t *testing.T) {
+	type args struct {
+		m1 Matrix
+		m2 Matrix
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Matrix
+		wantErr error
+	}{
+		{"ok", args{Matrix{{1, 2}, {3, 4}}, Matrix{{1, 1}, {1, 1}}}, Matrix{{2, 3}, {4, 5}}, nil},
+		{"mismatch", args{Matrix{{1, 2}, {3, 4}}, Matrix{{1, 1}}}, nil, ErrDimensionMismatch},
+		{"ragged", args{Matrix{{1, 2}, {3}}, Matrix{{1, 1}, {1, 1}}}, nil, ErrDimensionMismatch},
+		{"empty", args{Matrix{}, Matrix{{1, 1}}}, nil, ErrEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddE(tt.args.m1, tt.args.m2)
+			if err != tt.wantErr {
+				t.Fatalf("AddE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMulE(t *testing.T) {
+	type args struct {
+		m1 Matrix
+		m2 Matrix
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Matrix
+		wantErr error
+	}{
+		{"ok", args{Matrix{{1, 2}}, Matrix{{3}, {4}}}, Matrix{{11}}, nil},
+		{"inner sizes", args{Matrix{{1, 2}}, Matrix{{3, 4}}}, nil, ErrDimensionMismatch},
+		{"empty", args{Matrix{{1, 2}}, Matrix{}}, nil, ErrEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MulE(tt.args.m1, tt.args.m2)
+			if err != tt.wantErr {
+				t.Fatalf("MulE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MulE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRowOperationsE(t *testing.T) {
+	m := Matrix{{2, 4}, {0, 1}}
+
+	if _, err := m.DivideRowE(0, 0); err != ErrSingular {
+		t.Errorf("DivideRowE() error = %v, want %v", err, ErrSingular)
+	}
+	if _, err := m.SubstractRowE(0, 2, 1); err != ErrIndexOutOfRange {
+		t.Errorf("SubstractRowE() error = %v, want %v", err, ErrIndexOutOfRange)
+	}
+	if _, err := m.BaseVectorE(1, 0); err != ErrSingular {
+		t.Errorf("BaseVectorE() error = %v, want %v", err, ErrSingular)
+	}
+	if _, err := m.DivideRowE(0, 1e-17); err != ErrSingular {
+		t.Errorf("DivideRowE() error = %v, want %v", err, ErrSingular)
+	}
+	if _, err := (Matrix{{2, 4}, {1e-17, 1}}).BaseVectorE(1, 0); err != ErrSingular {
+		t.Errorf("BaseVectorE() error = %v, want %v", err, ErrSingular)
+	}
+	if _, err := m.FillWithE(Matrix{{1, 2, 3}}); err != ErrDimensionMismatch {
+		t.Errorf("FillWithE() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	if _, err := (Vector{1, 2}).MultiplyElementByElementE(Vector{1}); err != ErrDimensionMismatch {
+		t.Errorf("MultiplyElementByElementE() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+
+	got, err := m.BaseVectorE(0, 0)
+	if err != nil {
+		t.Fatalf("BaseVectorE() error = %v", err)
+	}
+	if want := (Matrix{{1, 2}, {0, 1}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("BaseVectorE() = %v, want %v", got, want)
+	}
+}
+
+func almostEqualM(m1, m2 Matrix) bool {
+	if len(m1) != len(m2) {
+		return false
+	}
+
+	for y := range m1 {
+		if !almostEqualV(m1[y], m2[y]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func almostEqualV(v1, v2 Vector) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+
+	for i := range v1 {
+		if math.Abs(v1[i]-v2[i]) > 1e-9 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestDet(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Matrix
+		want    float64
+		wantErr error
+	}{
+		{"2x2", Matrix{{1, 2}, {3, 4}}, -2, nil},
+		{"pivoting", Matrix{{0, 1, 2}, {1, 0, 3}, {4, -3, 8}}, -2, nil},
+		{"singular", Matrix{{1, 2}, {2, 4}}, 0, nil},
+		{"ill-conditioned", Matrix{{1, 1}, {1, 1 + 1e-9}}, 1e-9, ErrIllConditioned},
+		{"not square", Matrix{{1, 2}}, 0, ErrDimensionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Det()
+			if err != tt.wantErr {
+				t.Fatalf("Det() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Det() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the pivots left by the rounding errors are zeros
+	if got, err := (Matrix{{.1, .2, .3}, {.4, .5, .6}, {.7, .8, .9}}).Det(); err != nil || got != 0 {
+		t.Errorf("Det() = %v, %v, want 0", got, err)
+	}
+}
+
+func TestInverse(t *testing.T) {
+	m := Matrix{{0, 1, 2}, {1, 0, 3}, {4, -3, 8}}
+
+	got, err := m.Inverse()
+	if err != nil {
+		t.Fatalf("Inverse() error = %v", err)
+	}
+	if want := (Matrix{{-4.5, 7, -1.5}, {-2, 4, -1}, {1.5, -2, 0.5}}); !almostEqualM(got, want) {
+		t.Errorf("Inverse() = %v, want %v", got, want)
+	}
+
+	if _, err := (Matrix{{1, 2}, {2, 4}}).Inverse(); err != ErrSingular {
+		t.Errorf("Inverse() error = %v, want %v", err, ErrSingular)
+	}
+	if _, err := (Matrix{{1, 1}, {1, 1 + 1e-10}}).Inverse(); err != ErrIllConditioned {
+		t.Errorf("Inverse() error = %v, want %v", err, ErrIllConditioned)
+	}
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix
+		want int
+	}{
+		{"full", Matrix{{1, 2}, {3, 4}}, 2},
+		{"dependent rows", Matrix{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}}, 2},
+		{"wide", Matrix{{1, 2, 3, 4}, {2, 4, 6, 8}}, 1},
+		{"zero", Matrix{{0, 0}, {0, 0}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Rank(); got != tt.want {
+				t.Errorf("Rank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRREF(t *testing.T) {
+	got, pivots := Matrix{{1, 2, 1, 0}, {2, 4, 0, 1}, {0, 0, 1, 1}}.RREF()
+	want := Matrix{{1, 2, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+	if !almostEqualM(got, want) {
+		t.Errorf("RREF() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(pivots, []int{0, 2, 3}) {
+		t.Errorf("RREF() pivots = %v, want %v", pivots, []int{0, 2, 3})
+	}
+}
+
+func TestGaussWithPivoting(t *testing.T) {
+	m := Matrix{
+		{1, -2, 1, 1},
+		{2, 3, -1, -1},
+		{1, -1, 2, 0},
+	}
+	tests := []struct {
+		name     string
+		pivoting Pivoting
+		want     Matrix
+	}{
+		{"none", PivotingNone, Matrix{{1, 0, 0, 0.2}, {0, 1, 0, -0.6}, {0, 0, 1, -0.4}}},
+		{"partial", PivotingPartial, Matrix{{1, 0, 0, 0.2}, {0, 1, 0, -0.6}, {0, 0, 1, -0.4}}},
+		{"complete", PivotingComplete, Matrix{{0, 1, 0, -0.6}, {1, 0, 0, 0.2}, {0, 0, 1, -0.4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.GaussWithPivoting(tt.pivoting); !almostEqualM(got, tt.want) {
+				t.Errorf("GaussWithPivoting() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the tiny pivot is skipped by the partial pivoting
+	got := Matrix{{1e-20, 1, 1}, {1, 1, 2}}.GaussWithPivoting(PivotingPartial)
+	if want := (Matrix{{1, 0, 1}, {0, 1, 1}}); !almostEqualM(got, want) {
+		t.Errorf("GaussWithPivoting() = %v, want %v", got, want)
+	}
+}
+
+func TestEpsilon(t *testing.T) {
+	defer func(epsilon float64) { Epsilon = epsilon }(Epsilon)
+
+	if !IsZero(1e-13) || IsZero(1e-11) || !Equal(1e6, 1e6+1e-7) || Equal(1, 1.001) {
+		t.Errorf("comparisons with Epsilon = %v are wrong", Epsilon)
+	}
+
+	Epsilon = 1e-2
+	if !IsZero(1e-3) || !Equal(1, 1.001) {
+		t.Errorf("comparisons with Epsilon = %v are wrong", Epsilon)
+	}
+	if got := (Matrix{{1, 2}, {1, 2.001}}).Rank(); got != 1 {
+		t.Errorf("Rank() = %v, want %v", got, 1)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Matrix
+		b       Vector
+		want    Vector
+		wantErr error
+	}{
+		{"square", Matrix{{1, -2, 1}, {2, 3, -1}, {1, -1, 2}}, Vector{1, -1, 0}, Vector{0.2, -0.6, -0.4}, nil},
+		{"wide", Matrix{{1, 1, 1}, {0, 1, 2}}, Vector{3, 3}, Vector{0, 3, 0}, nil},
+		{"tall consistent", Matrix{{1, 0}, {0, 1}, {1, 1}}, Vector{1, 2, 3}, Vector{1, 2}, nil},
+		{"tall inconsistent", Matrix{{1, 0}, {0, 1}, {1, 1}}, Vector{1, 2, 4}, nil, ErrInconsistent},
+		{"singular", Matrix{{1, 2}, {2, 4}}, Vector{1, 2}, nil, ErrSingular},
+		{"wrong b", Matrix{{1, 2}, {2, 4}}, Vector{1}, nil, ErrDimensionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Solve(tt.b)
+			if err != tt.wantErr {
+				t.Fatalf("Solve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !almostEqualV(got, tt.want) {
+				t.Errorf("Solve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLU(t *testing.T) {
+	m := Matrix{{0, 1, 2}, {1, 0, 3}, {4, -3, 8}}
+
+	lu, err := NewLU(m)
+	if err != nil {
+		t.Fatalf("NewLU() error = %v", err)
+	}
+	if got, want := Multiply(lu.L(), lu.U()), Multiply(lu.P(), m); !almostEqualM(got, want) {
+		t.Errorf("NewLU() L * U = %v, want P * A = %v", got, want)
+	}
+	if got := lu.Det(); math.Abs(got+2) > 1e-9 {
+		t.Errorf("Det() = %v, want -2", got)
+	}
+
+	for _, b := range []Vector{{1, 2, 3}, {0, 0, 1}} {
+		x, err := lu.Solve(b)
+		if err != nil {
+			t.Fatalf("Solve() error = %v", err)
+		}
+		if got := Multiply(m, Matrix{x}.Transpose()).Transpose()[0]; !almostEqualV(got, b) {
+			t.Errorf("Solve() A * x = %v, want %v", got, b)
+		}
+	}
+
+	u, v := Vector{1, 0, 2}, Vector{0, 1, 1}
+	updated, err := lu.Update(u, v)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := Add(m, Multiply(Matrix{u}.Transpose(), Matrix{v}))
+	if got := Multiply(updated.L(), updated.U()); !almostEqualM(got, Multiply(updated.P(), want)) {
+		t.Errorf("Update() L * U = %v, want %v", got, Multiply(updated.P(), want))
+	}
+
+	big := randomMatrix(60, 60)
+	bigLU, err := NewLU(big)
+	if err != nil {
+		t.Fatalf("NewLU() error = %v", err)
+	}
+	if got, want := Multiply(bigLU.L(), bigLU.U()), Multiply(bigLU.P(), big); !almostEqualM(got, want) {
+		t.Errorf("NewLU() L * U differs from P * A for the random matrix")
+	}
+
+	singular, _ := NewLU(Matrix{{1, 2}, {2, 4}})
+	if _, err := singular.Solve(Vector{1, 1}); err != ErrSingular {
+		t.Errorf("Solve() error = %v, want %v", err, ErrSingular)
+	}
+}
+
+func TestQR(t *testing.T) {
+	m := Matrix{{1, 1}, {1, 2}, {1, 3}}
+
+	qr, err := NewQR(m)
+	if err != nil {
+		t.Fatalf("NewQR() error = %v", err)
+	}
+	if got := Multiply(qr.Q(), qr.R()); !almostEqualM(got, m) {
+		t.Errorf("NewQR() Q * R = %v, want %v", got, m)
+	}
+	if got := Multiply(qr.Q().Transpose(), qr.Q()); !almostEqualM(got, Identity(3)) {
+		t.Errorf("NewQR() Q^T * Q = %v, want identity", got)
+	}
+
+	// least squares line through (1, 1), (2, 2), (3, 2)
+	x, err := qr.Solve(Vector{1, 2, 2})
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if want := (Vector{2.0 / 3, 0.5}); !almostEqualV(x, want) {
+		t.Errorf("Solve() = %v, want %v", x, want)
+	}
+
+	u, v := Vector{1, -1, 2}, Vector{3, 1}
+	updated, err := qr.Update(u, v)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := Add(m, Multiply(Matrix{u}.Transpose(), Matrix{v}))
+	if got := Multiply(updated.Q(), updated.R()); !almostEqualM(got, want) {
+		t.Errorf("Update() Q * R = %v, want %v", got, want)
+	}
+	for y, row := range updated.R() {
+		for x := 0; x < y && x < len(row); x++ {
+			if math.Abs(row[x]) > 1e-9 {
+				t.Fatalf("Update() R = %v is not triangular", updated.R())
+			}
+		}
+	}
+
+	square, _ := NewQR(Matrix{{0, 1, 2}, {1, 0, 3}, {4, -3, 8}})
+	if det, err := square.Det(); err != nil || math.Abs(det+2) > 1e-9 {
+		t.Errorf("Det() = %v, %v, want -2", det, err)
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	m := Matrix{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}}
+
+	ch, err := NewCholesky(m)
+	if err != nil {
+		t.Fatalf("NewCholesky() error = %v", err)
+	}
+	if want := (Matrix{{2, 0, 0}, {6, 1, 0}, {-8, 5, 3}}); !almostEqualM(ch.L(), want) {
+		t.Errorf("NewCholesky() L = %v, want %v", ch.L(), want)
+	}
+	if got := ch.Det(); math.Abs(got-36) > 1e-9 {
+		t.Errorf("Det() = %v, want 36", got)
+	}
+
+	b := Vector{1, 2, 3}
+	x, err := ch.Solve(b)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if got := Multiply(m, Matrix{x}.Transpose()).Transpose()[0]; !almostEqualV(got, b) {
+		t.Errorf("Solve() A * x = %v, want %v", got, b)
+	}
+
+	v := Vector{1, 2, 0}
+	updated, err := ch.Update(v)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := Add(m, Multiply(Matrix{v}.Transpose(), Matrix{v}))
+	if got := Multiply(updated.L(), updated.L().Transpose()); !almostEqualM(got, want) {
+		t.Errorf("Update() L * L^T = %v, want %v", got, want)
+	}
+
+	downdated, err := updated.Downdate(v)
+	if err != nil {
+		t.Fatalf("Downdate() error = %v", err)
+	}
+	if !almostEqualM(downdated.L(), ch.L()) {
+		t.Errorf("Downdate() L = %v, want %v", downdated.L(), ch.L())
+	}
+
+	if _, err := NewCholesky(Matrix{{1, 2}, {2, 1}}); err != ErrNotPositiveDefinite {
+		t.Errorf("NewCholesky() error = %v, want %v", err, ErrNotPositiveDefinite)
+	}
+}
+
+func TestEigenSymmetric(t *testing.T) {
+	m := Matrix{{4, 1, 2}, {1, 3, 0}, {2, 0, 5}}
+	eigen, err := NewEigenSymmetric(m)
+	if err != nil {
+		t.Fatalf("NewEigenSymmetric() error = %v", err)
+	}
+
+	values, vectors := eigen.Values(), eigen.Vectors()
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1] {
+			t.Errorf("Values() = %v are not sorted", values)
+		}
+	}
+
+	for i, value := range values {
+		vector := vectors.GetColumn(i)
+		if got, want := m.multiplyVector(vector), vector.MultiplyWithNumber(value); !almostEqualV(got, want) {
+			t.Errorf("A * v = %v, want %v", got, want)
+		}
+	}
+	if got := Multiply(vectors.Transpose(), vectors); !almostEqualM(got, Identity(3)) {
+		t.Errorf("V^T * V = %v, want identity", got)
+	}
+
+	if got := values.Sum(); math.Abs(got-12) > 1e-9 {
+		t.Errorf("sum of Values() = %v, want trace %v", got, 12)
+	}
+
+	if _, err := NewEigenSymmetric(Matrix{{1, 2}, {3, 4}}); err != ErrNotSymmetric {
+		t.Errorf("NewEigenSymmetric() error = %v, want %v", err, ErrNotSymmetric)
+	}
+}
+
+func TestPowerIteration(t *testing.T) {
+	value, vector, err := Matrix{{2, 1}, {1, 2}}.PowerIteration(1000, 1e-10)
+	if err != nil {
+		t.Fatalf("PowerIteration() error = %v", err)
+	}
+	if math.Abs(value-3) > 1e-9 || !almostEqualV(vector, Vector{math.Sqrt2 / 2, math.Sqrt2 / 2}) {
+		t.Errorf("PowerIteration() = %v, %v, want %v, %v", value, vector, 3, Vector{math.Sqrt2 / 2, math.Sqrt2 / 2})
+	}
+
+	// eigenvalues 1 and -1 have the same absolute value
+	if _, _, err := (Matrix{{1, 0}, {0, -1}}).PowerIteration(1000, 1e-10); err != ErrNotConverged {
+		t.Errorf("PowerIteration() error = %v, want %v", err, ErrNotConverged)
+	}
+}
+
+func TestEigenvalues(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix
+		want []complex128
+	}{
+		{"rotation", Matrix{{0, -1}, {1, 0}}, []complex128{1i, -1i}},
+		{"non-symmetric", Matrix{{4, 1}, {2, 3}}, []complex128{5, 2}},
+		{"symmetric", Matrix{{2, 0, 0}, {0, 3, 4}, {0, 4, 9}}, []complex128{11, 2, 1}},
+		{"triangular", Matrix{{1, 2, 3}, {0, 4, 5}, {0, 0, 6}}, []complex128{6, 4, 1}},
+		{"complex pair", Matrix{{1, -2, 0}, {2, 1, 0}, {1, 1, 3}}, []complex128{3, 1 + 2i, 1 - 2i}},
+		{"cyclic permutation", Matrix{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}},
+			[]complex128{1, complex(-0.5, math.Sqrt(3)/2), complex(-0.5, -math.Sqrt(3)/2)}},
+		{"real and complex pair", Matrix{{-4, -8, -3}, {1, 5, -2}, {6, 7, -3}},
+			[]complex128{3.7552604306480517, -2.877630215324028 + 5.507426450452271i, -2.877630215324028 - 5.507426450452271i}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Eigenvalues()
+			if err != nil {
+				t.Fatalf("Eigenvalues() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Eigenvalues() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if cmplx.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("Eigenvalues() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEigenvaluesRandom(t *testing.T) {
+	// the sum of the eigenvalues is the trace and the product is the determinant
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		n := 1 + r.Intn(6)
+		m := ShellM(n, n)
+		for _, row := range m {
+			for x := range row {
+				row[x] = float64(r.Intn(11) - 5)
+			}
+		}
+
+		values, err := m.Eigenvalues()
+		if err != nil {
+			t.Fatalf("Eigenvalues(%v) error = %v", m, err)
+		}
+
+		trace, sum, product := 0.0, complex(0, 0), complex(1, 0)
+		for i, value := range values {
+			trace += m[i][i]
+			sum += value
+			product *= value
+		}
+		det, _ := m.Det()
+
+		if len(values) != n || cmplx.Abs(sum-complex(trace, 0)) > 1e-9 || cmplx.Abs(product-complex(det, 0)) > 1e-6*math.Max(1, math.Abs(det)) {
+			t.Errorf("Eigenvalues(%v) = %v, want the sum %v and the product %v", m, values, trace, det)
+		}
+	}
+}
+
+func TestSVD(t *testing.T) {
+	for _, m := range []Matrix{
+		{{3, 2, 2}, {2, 3, -2}},
+		{{1, 2}, {3, 4}, {5, 6}},
+		{{1, 2}, {2, 4}},
+	} {
+		svd, err := NewSVD(m)
+		if err != nil {
+			t.Fatalf("NewSVD() error = %v", err)
+		}
+
+		u, values, v := svd.U(), svd.Values(), svd.V()
+		scaled := u.Clone()
+		for _, row := range scaled {
+			for x := range row {
+				row[x] *= values[x]
+			}
+		}
+		if got := Multiply(scaled, v.Transpose()); !almostEqualM(got, m) {
+			t.Errorf("U * S * V^T = %v, want %v", got, m)
+		}
+	}
+
+	svd, _ := NewSVD(Matrix{{3, 2, 2}, {2, 3, -2}})
+	if got := svd.Values(); !almostEqualV(got, Vector{5, 3}) {
+		t.Errorf("Values() = %v, want %v", got, Vector{5, 3})
+	}
+	if got := svd.Condition(); math.Abs(got-5.0/3) > 1e-9 {
+		t.Errorf("Condition() = %v, want %v", got, 5.0/3)
+	}
+
+	// U of the rank deficient matrices still has orthonormal columns
+	for _, m := range []Matrix{{{1, 2}, {2, 4}}, {{1, 1}, {1, 1}, {1, 1}}, {{0, 0}, {0, 0}, {0, 0}}} {
+		svd, err := NewSVD(m)
+		if err != nil {
+			t.Fatalf("NewSVD() error = %v", err)
+		}
+		u := svd.U()
+		if got := Multiply(u.Transpose(), u); !almostEqualM(got, Identity(u.Width())) {
+			t.Errorf("NewSVD(%v) U^T * U = %v, want identity", m, got)
+		}
+	}
+
+	singular, _ := NewSVD(Matrix{{1, 2}, {2, 4}})
+	if got := singular.Rank(); got != 1 {
+		t.Errorf("Rank() = %v, want %v", got, 1)
+	}
+	if got := singular.Condition(); !math.IsInf(got, 1) {
+		t.Errorf("Condition() = %v, want +Inf", got)
+	}
+	if got, want := singular.PseudoInverse(), (Matrix{{0.04, 0.08}, {0.08, 0.16}}); !almostEqualM(got, want) {
+		t.Errorf("PseudoInverse() = %v, want %v", got, want)
+	}
+
+	// pseudo-inverse of the tall matrix of full rank is the left inverse
+	tall := Matrix{{1, 2}, {3, 4}, {5, 6}}
+	inverse, err := tall.PseudoInverse()
+	if err != nil {
+		t.Fatalf("PseudoInverse() error = %v", err)
+	}
+	if got := Multiply(inverse, tall); !almostEqualM(got, Identity(2)) {
+		t.Errorf("PseudoInverse() * A = %v, want identity", got)
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// y = 1 + 2x with the residuals -0.5, 1, -0.5
+	a := Matrix{{1, 0}, {1, 1}, {1, 2}}
+	b := Vector{0.5, 4, 4.5}
+	for _, method := range []LeastSquaresMethod{LeastSquaresQR, LeastSquaresNormal, LeastSquaresPseudoInverse} {
+		fit, err := LeastSquares(a, b, method)
+		if err != nil {
+			t.Fatalf("LeastSquares(%v) error = %v", method, err)
+		}
+		if !almostEqualV(fit.Coefficients, Vector{1, 2}) {
+			t.Errorf("LeastSquares(%v) = %v, want %v", method, fit.Coefficients, Vector{1, 2})
+		}
+		if !almostEqualV(fit.Residuals, Vector{-0.5, 1, -0.5}) {
+			t.Errorf("LeastSquares(%v) residuals = %v, want %v", method, fit.Residuals, Vector{-0.5, 1, -0.5})
+		}
+		if math.Abs(fit.ResidualSumOfSquares-1.5) > 1e-9 || math.Abs(fit.RSquared-(1-1.5/9.5)) > 1e-9 {
+			t.Errorf("LeastSquares(%v) RSS = %v, R2 = %v", method, fit.ResidualSumOfSquares, fit.RSquared)
+		}
+	}
+
+	// the second column duplicates the first one
+	deficient := Matrix{{1, 1}, {1, 1}, {1, 1}}
+	if _, err := LeastSquares(deficient, Vector{1, 2, 3}, LeastSquaresNormal); err != ErrSingular {
+		t.Errorf("LeastSquares() error = %v, want %v", err, ErrSingular)
+	}
+	fit, err := LeastSquares(deficient, Vector{1, 2, 3}, LeastSquaresPseudoInverse)
+	if err != nil || !almostEqualV(fit.Coefficients, Vector{1, 1}) {
+		t.Errorf("LeastSquares() = %v, %v, want %v", fit.Coefficients, err, Vector{1, 1})
+	}
+}
+
+func TestWeightedLeastSquares(t *testing.T) {
+	// zero weight drops the outlier
+	a := Matrix{{1, 0}, {1, 1}, {1, 2}, {1, 3}}
+	b := Vector{1, 3, 5, 100}
+	fit, err := WeightedLeastSquares(a, b, Vector{1, 1, 1, 0}, LeastSquaresQR)
+	if err != nil {
+		t.Fatalf("WeightedLeastSquares() error = %v", err)
+	}
+	if !almostEqualV(fit.Coefficients, Vector{1, 2}) || math.Abs(fit.RSquared-1) > 1e-9 {
+		t.Errorf("WeightedLeastSquares() = %v, R2 = %v", fit.Coefficients, fit.RSquared)
+	}
+	if math.Abs(fit.Residuals[3]-93) > 1e-9 {
+		t.Errorf("WeightedLeastSquares() residuals = %v", fit.Residuals)
+	}
+
+	if _, err := WeightedLeastSquares(a, b, Vector{1, 1, -1, 1}, LeastSquaresQR); err != ErrNegativeWeight {
+		t.Errorf("WeightedLeastSquares() error = %v, want %v", err, ErrNegativeWeight)
+	}
+}
+
+func TestPolynomialFit(t *testing.T) {
+	x := Vector{-2, -1, 0, 1, 2, 3}
+	y := ShellV(len(x))
+	for i, value := range x {
+		y[i] = Polynomial(Vector{1, -2, 0.5}, value)
+	}
+
+	fit, err := PolynomialFit(x, y, 2, LeastSquaresQR)
+	if err != nil {
+		t.Fatalf("PolynomialFit() error = %v", err)
+	}
+	if !almostEqualV(fit.Coefficients, Vector{1, -2, 0.5}) || math.Abs(fit.RSquared-1) > 1e-9 {
+		t.Errorf("PolynomialFit() = %v, R2 = %v", fit.Coefficients, fit.RSquared)
+	}
+
+	if _, err := PolynomialFit(x, y[1:], 2, LeastSquaresQR); err != ErrDimensionMismatch {
+		t.Errorf("PolynomialFit() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+}
+
+func TestMatrixOf(t *testing.T) {
+	// exact elimination finds the dependent rows without any tolerance
+	dependent := MatrixFrom[Rational](Matrix{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	if got := dependent.Rank(); got != 2 {
+		t.Errorf("Rank() = %v, want %v", got, 2)
+	}
+	if _, err := dependent.Solve(VectorFrom[Rational](Vector{1, 1, 2})); err != ErrInconsistent {
+		t.Errorf("Solve() error = %v, want %v", err, ErrInconsistent)
+	}
+
+	// Hilbert matrix has the inverse of integers, exact arithmetic keeps them exact
+	hilbert := ShellMOf[Rational](3, 3)
+	for y := range hilbert {
+		for x := range hilbert[y] {
+			hilbert[y][x] = NewRational(1, int64(x+y+1))
+		}
+	}
+	inverse, err := hilbert.Inverse()
+	if err != nil {
+		t.Fatalf("Inverse() error = %v", err)
+	}
+	want := "9 -36 30 \n-36 192 -180 \n30 -180 180 \n"
+	if got := inverse.String(); got != want {
+		t.Errorf("Inverse() = %q, want %q", got, want)
+	}
+	if det, _ := hilbert.Det(); det.String() != "1/2160" {
+		t.Errorf("Det() = %v, want %v", det, "1/2160")
+	}
+	product, err := MultiplyOf(hilbert, inverse)
+	if err != nil || product.String() != IdentityOf[Rational](3).String() {
+		t.Errorf("MultiplyOf() = %v, %v, want identity", product, err)
+	}
+
+	complexSystem := MatrixOf[Complex128]{{1, 1i}, {1i, 2}}
+	x, err := complexSystem.Solve(VectorOf[Complex128]{1 + 1i, 2 + 1i})
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if cmplx.Abs(complex128(x[0]-1)) > 1e-9 || cmplx.Abs(complex128(x[1]-1)) > 1e-9 {
+		t.Errorf("Solve() = %v, want %v", x, VectorOf[Complex128]{1, 1})
+	}
+
+	floats := MatrixFrom[Float32](Matrix{{1, 2}, {3, 4}})
+	sum, _ := AddOf(floats, floats)
+	if got := sum[1].Sum(); got != 14 {
+		t.Errorf("AddOf() row sum = %v, want %v", got, 14)
+	}
+	if _, err := MultiplyOf(floats, MatrixFrom[Float32](Matrix{{1, 2}})); err != ErrDimensionMismatch {
+		t.Errorf("MultiplyOf() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	if _, err := (MatrixOf[Rational]{{NewRational(1, 2), NewRational(1, 3)}, {NewRational(3, 2), NewRational(1, 1)}}).Inverse(); err != ErrSingular {
+		t.Errorf("Inverse() error = %v, want %v", err, ErrSingular)
+	}
+
+	// the rounding errors of single precision are bigger than Epsilon
+	singular := MatrixFrom[Float32](Matrix{{.1, .2, .3}, {.4, .5, .6}, {.7, .8, .9}})
+	if _, err := singular.Inverse(); err != ErrSingular {
+		t.Errorf("Inverse() error = %v, want %v", err, ErrSingular)
+	}
+	if det, _ := singular.Det(); det != 0 {
+		t.Errorf("Det() = %v, want 0", det)
+	}
+}
+
+// TestSimplexOf runs the simplex steps with exact numbers, the ratios 1/3 and 2/6 are the same
+// only without the rounding
+func TestSimplexOf(t *testing.T) {
+	m := MatrixOf[Rational]{
+		{NewRational(3, 1), NewRational(1, 1), NewRational(1, 1)},
+		{NewRational(6, 1), NewRational(1, 1), NewRational(2, 1)},
+	}
+	if got := PivotRowOf(m, 0, []int{2, 1}, 0); got != 1 {
+		t.Errorf("PivotRowOf() = %v, want %v", got, 1)
+	}
+
+	negative := MatrixFrom[Rational](Matrix{
+		{1, 1, -1, 0, 2},
+		{-1, 1, 0, 1, -1},
+	})
+	got, err := OriginalBaseVectorOf(negative)
+	if err != nil {
+		t.Fatalf("OriginalBaseVectorOf() error = %v", err)
+	}
+	// b column is not a base one, so the basis ends with 0
+	if want := "3/2 1/2 0 0 0 "; got.GetBasis().String() != want {
+		t.Errorf("OriginalBaseVectorOf() basis = %q, want %q", got.GetBasis().String(), want)
+	}
+	if _, err := OriginalBaseVectorOf(MatrixFrom[Float32](Matrix{{1, 1, 1, -1}})); err != ErrInfeasible {
+		t.Errorf("OriginalBaseVectorOf() error = %v, want %v", err, ErrInfeasible)
+	}
+
+	floats := MatrixFrom[Float32](Matrix{{-1, 2}, {3, -4}})
+	if max, min := MaxOf(floats), MinOf(floats); max != 3 || min != -4 {
+		t.Errorf("MaxOf(), MinOf() = %v, %v, want 3, -4", max, min)
+	}
+	if got := floats.FillWith(MatrixOf[Float32]{{5}}); got[0][0] != 5 || got[1][1] != -4 {
+		t.Errorf("FillWith() = %v", got)
+	}
+}
+
+// randomMatrix generates the matrix of the pseudo-random values, the sequence is the same for every run
+func randomMatrix(width, height int) Matrix {
+	m := ShellM(width, height)
+	seed := uint32(1)
+	for _, row := range m {
+		for x := range row {
+			seed = seed*1664525 + 1013904223
+			row[x] = float64(seed%2000)/100 - 10
+		}
+	}
+
+	return m
+}
+
+// baseVectorSequential is BaseVector substracting the rows one by one with the matrix copies
+func baseVectorSequential(m Matrix, rowIndex, columnIndex int) Matrix {
+	mr := m.DivideRow(rowIndex, m[rowIndex][columnIndex])
+	for y, row := range m {
+		if y != rowIndex {
+			mr = mr.SubstractRow(rowIndex, y, row[columnIndex])
+		}
+	}
+
+	return mr
+}
+
+func TestMultiplyParallel(t *testing.T) {
+	for _, size := range [][3]int{{1, 1, 1}, {3, 5, 2}, {70, 130, 90}, {200, 150, 100}} {
+		m1 := randomMatrix(size[1], size[0])
+		m2 := randomMatrix(size[2], size[1])
+		want := Multiply(m1, m2)
+		for _, workers := range []int{0, 1, 3} {
+			if got, err := MultiplyParallel(m1, m2, workers); err != nil || !almostEqualM(got, want) {
+				t.Errorf("MultiplyParallel(%v, workers %d) differs from Multiply(), error = %v", size, workers, err)
+			}
+		}
+	}
+
+	for _, m2 := range []Matrix{{{1}, {2}, {3}, {4}}, {{1}, {2}}} {
+		if _, err := MultiplyParallel(Matrix{{1, 2, 3}}, m2, 0); err != ErrDimensionMismatch {
+			t.Errorf("MultiplyParallel() error = %v, want %v", err, ErrDimensionMismatch)
+		}
+	}
+}
+
+func TestBaseVectorParallel(t *testing.T) {
+	m := randomMatrix(150, 120)
+	want := baseVectorSequential(m, 7, 11)
+	for _, workers := range []int{0, 1, 4} {
+		if got := m.BaseVectorParallel(7, 11, workers); !almostEqualM(got, want) {
+			t.Errorf("BaseVectorParallel(workers %d) differs from the sequential one", workers)
+		}
+	}
+	if got := m.BaseVector(7, 11); !almostEqualM(got, want) {
+		t.Errorf("BaseVector() differs from the sequential one")
+	}
+	if m[7][11] == 1 {
+		t.Errorf("BaseVectorParallel() changed the source matrix")
+	}
+}
+
+func BenchmarkMultiply(b *testing.B) {
+	for _, size := range []int{64, 256} {
+		m1, m2 := randomMatrix(size, size), randomMatrix(size, size)
+		b.Run(fmt.Sprintf("naive-%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Multiply(m1, m2)
+			}
+		})
+		b.Run(fmt.Sprintf("blocked-%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MultiplyParallel(m1, m2, 1)
+			}
+		})
+		b.Run(fmt.Sprintf("parallel-%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MultiplyParallel(m1, m2, 0)
+			}
+		})
+	}
+}
+
+func BenchmarkBaseVector(b *testing.B) {
+	m := randomMatrix(200, 200)
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			baseVectorSequential(m, 3, 5)
+		}
+	})
+	b.Run("single-worker", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m.BaseVector(3, 5)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m.BaseVectorParallel(3, 5, 0)
+		}
+	})
+}
+
+func TestCSV(t *testing.T) {
+	m, header, err := ReadCSV(strings.NewReader("a, b, c\n1, 2.5, -3\n4, 5, 6e2\n"), true)
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	if !reflect.DeepEqual(header, []string{"a", "b", "c"}) || !reflect.DeepEqual(m, Matrix{{1, 2.5, -3}, {4, 5, 600}}) {
+		t.Errorf("ReadCSV() = %v, %v", m, header)
+	}
+
+	var b strings.Builder
+	if err := WriteCSV(&b, m, header); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if want := "a,b,c\n1,2.5,-3\n4,5,600\n"; b.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", b.String(), want)
+	}
+
+	for _, input := range []string{"1,2\n3\n", "1,x\n", ""} {
+		if _, _, err := ReadCSV(strings.NewReader(input), false); err == nil {
+			t.Errorf("ReadCSV(%q) error = nil", input)
+		}
+	}
+
+	readErr := errors.New("read failed")
+	if _, _, err := ReadCSV(iotest.ErrReader(readErr), false); err != readErr {
+		t.Errorf("ReadCSV() error = %v, want %v", err, readErr)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	m := Matrix{{1, 2}, {0.1, -4}}
+	var b strings.Builder
+	if err := WriteJSON(&b, m); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	got, err := ReadJSON(strings.NewReader(b.String()))
+	if err != nil || !reflect.DeepEqual(got, m) {
+		t.Errorf("ReadJSON(WriteJSON()) = %v, %v, want %v", got, err, m)
+	}
+
+	if _, err := ReadJSON(strings.NewReader("[[1, 2], [3]]")); err != ErrDimensionMismatch {
+		t.Errorf("ReadJSON() error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	if v, err := ReadVectorJSON(strings.NewReader("[1, 2.5]")); err != nil || !reflect.DeepEqual(v, Vector{1, 2.5}) {
+		t.Errorf("ReadVectorJSON() = %v, %v", v, err)
+	}
+}
+
+func TestMatrixMarket(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Matrix
+	}{
+		{"coordinate", "%%MatrixMarket matrix coordinate real general\n% comment\n2 3 2\n1 1 1.5\n2 3 -2\n",
+			Matrix{{1.5, 0, 0}, {0, 0, -2}}},
+		{"array", "%%MatrixMarket matrix array integer general\n2 2\n1\n3\n2\n4\n", Matrix{{1, 2}, {3, 4}}},
+		{"symmetric", "%%MatrixMarket matrix coordinate real symmetric\n2 2 2\n1 1 1\n2 1 5\n", Matrix{{1, 5}, {5, 0}}},
+		{"skew-symmetric array", "%%MatrixMarket matrix array real skew-symmetric\n2 2\n3\n", Matrix{{0, -3}, {3, 0}}},
+		{"pattern", "%%MatrixMarket matrix coordinate pattern general\n2 2 1\n2 1\n", Matrix{{0, 0}, {1, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadMatrixMarket(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ReadMatrixMarket() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadMatrixMarket() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	m := Matrix{{1, 0, 2.5}, {0, -3, 0}}
+	for _, format := range []MatrixMarketFormat{MatrixMarketArray, MatrixMarketCoordinate} {
+		var b strings.Builder
+		if err := WriteMatrixMarket(&b, m, format); err != nil {
+			t.Fatalf("WriteMatrixMarket() error = %v", err)
+		}
+		got, err := ReadMatrixMarket(strings.NewReader(b.String()))
+		if err != nil || !reflect.DeepEqual(got, m) {
+			t.Errorf("ReadMatrixMarket(WriteMatrixMarket(%v)) = %v, %v, want %v", format, got, err, m)
+		}
+	}
+
+	for _, input := range []string{
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n1000000000 1000000000 1\n1 1 1\n",
+		"%%MatrixMarket matrix array real general\n1000000000 1000000000\n1\n",
+		"%%MatrixMarket matrix coordinate real general\n2.5 2 1\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1.9 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n1 1 2\n",
+		"%%MatrixMarket matrix coordinate real symmetric\n2 2 2\n2 1 1\n1 2 1\n",
+		"%%MatrixMarket matrix coordinate real skew-symmetric\n2 2 1\n1 1 1\n",
+	} {
+		if _, err := ReadMatrixMarket(strings.NewReader(input)); err != ErrFormat {
+			t.Errorf("ReadMatrixMarket(%q) error = %v, want %v", input, err, ErrFormat)
+		}
+	}
+}
+
+func TestHumaniazeValue(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{3, "3"},
+		{2.996, "3"},
+		{2.7, "2.700"},
+		{-2.7, "-2.700"},
+		{-0.001, "0"},
+		{0.5, "0.500"},
+	}
+	for _, tt := range tests {
+		if got := HumaniazeValue(tt.value); got != tt.want {
+			t.Errorf("HumaniazeValue(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	if got, want := (Vector{1, -0.6, 12.25}).String(), " 1.000 -0.600 12.250 "; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	fractions := Formatter{Precision: 2, Fractions: true}
+	for value, want := range map[float64]string{2.0 / 3: "2/3", -1.5: "-3/2", 4: "4", math.Pi: "3.14", -1e-15: "0"} {
+		if got := fractions.FormatValue(value); got != want {
+			t.Errorf("FormatValue(%v) = %v, want %v", value, got, want)
+		}
+	}
+
+	m := Matrix{{1, 0.5}, {-2, 100}}
+	tests := []struct {
+		name      string
+		formatter Formatter
+		want      string
+	}{
+		{"plain", Formatter{Precision: 1, RowLabels: []string{"x1", "x2"}, ColumnLabels: []string{"a", "b"}},
+			"      a     b \nx1  1.0   0.5 \nx2 -2.0 100.0 \n"},
+		{"markdown", Formatter{Precision: -1, Style: StyleMarkdown},
+			"|     |     |\n| --: | --: |\n|   1 | 0.5 |\n|  -2 | 100 |\n"},
+		{"latex", Formatter{Precision: 0, Humanize: true, ColumnLabels: []string{"a", "b"}, Style: StyleLaTeX},
+			"\\begin{tabular}{rr}\n a &   b \\\\\n\\hline\n 1 &   0 \\\\\n-2 & 100 \\\\\n\\end{tabular}\n"},
+		{"csv", Formatter{Fractions: true, ColumnLabels: []string{"a", "b"}, Style: StyleCSV},
+			"a,b\n1,1/2\n-2,100\n"},
+		{"markdown escaping", Formatter{Precision: -1, RowLabels: []string{"x_1", "a|b"}, Style: StyleMarkdown},
+			"|      |     |     |\n| ---- | --: | --: |\n| x\\_1 |   1 | 0.5 |\n| a\\|b |  -2 | 100 |\n"},
+		{"latex escaping", Formatter{Precision: -1, ColumnLabels: []string{"x_1", "50%"}, RowLabels: []string{"A&B", "#"}, Style: StyleLaTeX},
+			"\\begin{tabular}{lrr}\n     & x\\_1 & 50\\% \\\\\n\\hline\nA\\&B &    1 &  0.5 \\\\\n\\#   &   -2 &  100 \\\\\n\\end{tabular}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.FormatMatrix(m); got != tt.want {
+				t.Errorf("FormatMatrix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+// Number is the element of VectorOf and MatrixOf, the zero value of T must be the zero number
+type Number[T any] interface {
+	Add(T) T
+	Sub(T) T
+	Mul(T) T
+	Div(T) T
+	// IsZero checks if the number is zero within Tolerance
+	IsZero() bool
+	// Tolerance returns the precision of the type, the pivots are compared with it relatively
+	// to the biggest element. Exact types return 0
+	Tolerance() float64
+	// Abs returns the absolute value used to choose the pivots
+	Abs() float64
+	// One returns the multiplicative identity
+	One() T
+	// FromFloat64 converts the float to the number type
+	FromFloat64(float64) T
+	String() string
+}
+
+// Real is the Number with the order, the simplex method compares the elements with it
+type Real[T any] interface {
+	Number[T]
+	// Sign returns -1, 0 or +1, the number is compared with zero exactly
+	Sign() int
+	// Float64 converts the number to float
+	Float64() float64
+}
+
+// Float32 is the fast Number with single precision
+type Float32 float32
+
+// Float64 is the Number with the same arithmetic as Vector and Matrix
+type Float64 float64
+
+// Complex128 is the complex Number
+type Complex128 complex128
+
+// Rational is the exact Number, the zero value is 0
+type Rational struct {
+	r *big.Rat
+}
+
+// Add returns the sum
+func (f Float32) Add(g Float32) Float32 { return f + g }
+
+// Sub returns the difference
+func (f Float32) Sub(g Float32) Float32 { return f - g }
+
+// Mul returns the product
+func (f Float32) Mul(g Float32) Float32 { return f * g }
+
+// Div returns the quotient
+func (f Float32) Div(g Float32) Float32 { return f / g }
+
+// IsZero checks if the number is zero within Epsilon32
+func (f Float32) IsZero() bool { return math.Abs(float64(f)) <= Epsilon32 }
+
+// Tolerance returns Epsilon32
+func (f Float32) Tolerance() float64 { return Epsilon32 }
+
+// Abs returns the absolute value
+func (f Float32) Abs() float64 { return math.Abs(float64(f)) }
+
+// One returns 1
+func (f Float32) One() Float32 { return 1 }
+
+// FromFloat64 converts the float
+func (f Float32) FromFloat64(value float64) Float32 { return Float32(value) }
+
+// String converts the number to string
+func (f Float32) String() string { return fmt.Sprintf("%6.3f", f) }
+
+// Sign returns the sign
+func (f Float32) Sign() int { return sign(float64(f)) }
+
+// Float64 converts the number to float
+func (f Float32) Float64() float64 { return float64(f) }
+
+// Add returns the sum
+func (f Float64) Add(g Float64) Float64 { return f + g }
+
+// Sub returns the difference
+func (f Float64) Sub(g Float64) Float64 { return f - g }
+
+// Mul returns the product
+func (f Float64) Mul(g Float64) Float64 { return f * g }
+
+// Div returns the quotient
+func (f Float64) Div(g Float64) Float64 { return f / g }
+
+// IsZero checks if the number is zero within Epsilon
+func (f Float64) IsZero() bool { return IsZero(float64(f)) }
+
+// Tolerance returns Epsilon
+func (f Float64) Tolerance() float64 { return Epsilon }
+
+// Abs returns the absolute value
+func (f Float64) Abs() float64 { return math.Abs(float64(f)) }
+
+// One returns 1
+func (f Float64) One() Float64 { return 1 }
+
+// FromFloat64 converts the float
+func (f Float64) FromFloat64(value float64) Float64 { return Float64(value) }
+
+// String converts the number to string
+func (f Float64) String() string { return fmt.Sprintf("%6.3f", f) }
+
+// Sign returns the sign
+func (f Float64) Sign() int { return sign(float64(f)) }
+
+// Float64 converts the number to float
+func (f Float64) Float64() float64 { return float64(f) }
+
+// Add returns the sum
+func (c Complex128) Add(d Complex128) Complex128 { return c + d }
+
+// Sub returns the difference
+func (c Complex128) Sub(d Complex128) Complex128 { return c - d }
+
+// Mul returns the product
+func (c Complex128) Mul(d Complex128) Complex128 { return c * d }
+
+// Div returns the quotient
+func (c Complex128) Div(d Complex128) Complex128 { return c / d }
+
+// IsZero checks if the modulus is zero within Epsilon
+func (c Complex128) IsZero() bool { return IsZero(cmplx.Abs(complex128(c))) }
+
+// Tolerance returns Epsilon
+func (c Complex128) Tolerance() float64 { return Epsilon }
+
+// Abs returns the modulus
+func (c Complex128) Abs() float64 { return cmplx.Abs(complex128(c)) }
+
+// One returns 1
+func (c Complex128) One() Complex128 { return 1 }
+
+// FromFloat64 converts the float to the complex number with zero imaginary part
+func (c Complex128) FromFloat64(value float64) Complex128 { return Complex128(complex(value, 0)) }
+
+// String converts the number to string
+func (c Complex128) String() string {
+	return fmt.Sprintf("(%6.3f%+6.3fi)", real(c), imag(c))
+}
+
+// NewRational creates the fraction a / b, b must not be zero
+func NewRational(a, b int64) Rational {
+	return Rational{big.NewRat(a, b)}
+}
+
+// value returns the underlying big.Rat, it must not be modified
+func (r Rational) value() *big.Rat {
+	if r.r == nil {
+		return new(big.Rat)
+	}
+
+	return r.r
+}
+
+// Rat returns the copy of the value as big.Rat
+func (r Rational) Rat() *big.Rat {
+	return new(big.Rat).Set(r.value())
+}
+
+// Add returns the sum
+func (r Rational) Add(s Rational) Rational { return Rational{new(big.Rat).Add(r.value(), s.value())} }
+
+// Sub returns the difference
+func (r Rational) Sub(s Rational) Rational { return Rational{new(big.Rat).Sub(r.value(), s.value())} }
+
+// Mul returns the product
+func (r Rational) Mul(s Rational) Rational { return Rational{new(big.Rat).Mul(r.value(), s.value())} }
+
+// Div returns the quotient, it panics on division by zero as big.Rat does
+func (r Rational) Div(s Rational) Rational { return Rational{new(big.Rat).Quo(r.value(), s.value())} }
+
+// IsZero checks if the number is exactly zero
+func (r Rational) IsZero() bool { return r.r == nil || r.r.Sign() == 0 }
+
+// Tolerance returns 0, the rationals are exact
+func (r Rational) Tolerance() float64 { return 0 }
+
+// Abs returns the absolute value as float
+func (r Rational) Abs() float64 {
+	rat := r.Rat()
+	value, _ := rat.Abs(rat).Float64()
+	return value
+}
+
+// One returns 1
+func (r Rational) One() Rational { return NewRational(1, 1) }
+
+// FromFloat64 converts the float exactly, so 0.1 becomes its binary fraction
+func (r Rational) FromFloat64(value float64) Rational {
+	return Rational{new(big.Rat).SetFloat64(value)}
+}
+
+// String converts the number to the fraction string
+func (r Rational) String() string { return r.value().RatString() }
+
+// Sign returns the sign
+func (r Rational) Sign() int { return r.value().Sign() }
+
+// Float64 converts the number to the nearest float
+func (r Rational) Float64() float64 {
+	value, _ := r.value().Float64()
+	return value
+}
+
+func sign(value float64) int {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,113 @@
+package matrix
+
+import (
+	"runtime"
+	"sync"
+)
+
+const (
+	// blockSize is the side of the square blocks MultiplyParallel works with to stay in the cache
+	blockSize = 64
+	// parallelThreshold is the count of the operations the work isn't split among goroutines below
+	parallelThreshold = 1 << 14
+)
+
+// workersCount replaces non-positive count of workers with GOMAXPROCS
+func workersCount(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+
+	return workers
+}
+
+// parallelRows calls f for the ranges of rows [from, to) in the goroutines,
+// small work is done in the calling goroutine
+func parallelRows(height, work, workers int, f func(from, to int)) {
+	workers = minInt(workersCount(workers), height)
+	if workers <= 1 || work < parallelThreshold {
+		f(0, height)
+		return
+	}
+
+	var wg sync.WaitGroup
+	step := (height + workers - 1) / workers
+	for from := 0; from < height; from += step {
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			f(from, to)
+		}(from, minInt(from+step, height))
+	}
+	wg.Wait()
+}
+
+// MultiplyParallel multiplies the matrices as MulE does. The product is calculated by blocks
+// and the rows are split among the workers, non-positive count of workers means GOMAXPROCS
+func MultiplyParallel(m1, m2 Matrix, workers int) (Matrix, error) {
+	if err := m1.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := m2.Validate(); err != nil {
+		return nil, err
+	}
+
+	if m1.Width() != m2.Height() {
+		return nil, ErrDimensionMismatch
+	}
+
+	n := m1.Width()
+	h1 := m1.Height()
+	w2 := m2.Width()
+
+	mr := ShellM(w2, h1)
+	parallelRows(h1, h1*n*w2, workers, func(from, to int) {
+		for k0 := 0; k0 < n; k0 += blockSize {
+			k1 := minInt(k0+blockSize, n)
+			for x0 := 0; x0 < w2; x0 += blockSize {
+				x1 := minInt(x0+blockSize, w2)
+				for y := from; y < to; y++ {
+					row := mr[y][x0:x1]
+					for k := k0; k < k1; k++ {
+						a := m1[y][k]
+						for x, b := range m2[k][x0:x1] {
+							row[x] += a * b
+						}
+					}
+				}
+			}
+		}
+	})
+
+	return mr, nil
+}
+
+// BaseVectorParallel is BaseVector with the rows substracted in the workers,
+// non-positive count of workers means GOMAXPROCS
+func (m Matrix) BaseVectorParallel(rowIndex, columnIndex, workers int) Matrix {
+	w, h := m.Size()
+
+	pivotRow := m[rowIndex].Clone()
+	for x := range pivotRow {
+		pivotRow[x] /= m[rowIndex][columnIndex]
+	}
+
+	mr := make(Matrix, h)
+	parallelRows(h, w*h, workers, func(from, to int) {
+		for y := from; y < to; y++ {
+			if y == rowIndex {
+				mr[y] = pivotRow
+				continue
+			}
+
+			multiplier := m[y][columnIndex]
+			mr[y] = ShellV(w)
+			for x, el := range m[y] {
+				mr[y][x] = el - pivotRow[x]*multiplier
+			}
+		}
+	})
+
+	return mr
+}
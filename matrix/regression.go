@@ -0,0 +1,170 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNegativeWeight is returned when a weight of weighted least squares is negative
+var ErrNegativeWeight = errors.New("matrix: weight is negative")
+
+// LeastSquaresMethod is the way the least squares problem is solved
+type LeastSquaresMethod int
+
+const (
+	// LeastSquaresQR solves R * x = Q^T * b, it's stable and needs the full column rank
+	LeastSquaresQR LeastSquaresMethod = iota
+	// LeastSquaresNormal solves A^T * A * x = A^T * b with Cholesky decomposition, it's fast but squares the condition
+	LeastSquaresNormal
+	// LeastSquaresPseudoInverse returns the minimum norm solution, it works for rank deficient matrices too
+	LeastSquaresPseudoInverse
+)
+
+// Fit is the solution of the least squares problem with its diagnostics
+type Fit struct {
+	Coefficients Vector
+	// Residuals are b - A * x
+	Residuals Vector
+	// ResidualSumOfSquares is the weighted sum of the squared residuals
+	ResidualSumOfSquares float64
+	// RSquared is the coefficient of determination 1 - RSS / TSS, it's 1 when b is constant and fitted exactly
+	RSquared float64
+}
+
+// LeastSquares finds x minimizing |A * x - b|
+func LeastSquares(a Matrix, b Vector, method LeastSquaresMethod) (Fit, error) {
+	return WeightedLeastSquares(a, b, ShellVWithValue(len(b), 1), method)
+}
+
+// WeightedLeastSquares finds x minimizing the sum of w_i * (A_i * x - b_i)^2
+func WeightedLeastSquares(a Matrix, b, weights Vector, method LeastSquaresMethod) (Fit, error) {
+	if err := a.Validate(); err != nil {
+		return Fit{}, err
+	}
+
+	if len(b) != a.Height() || len(weights) != a.Height() {
+		return Fit{}, ErrDimensionMismatch
+	}
+
+	// the weighted problem is the ordinary one with the rows multiplied by the square roots of the weights
+	scaledA := a.Clone()
+	scaledB := b.Clone()
+	for y, weight := range weights {
+		if weight < 0 {
+			return Fit{}, ErrNegativeWeight
+		}
+
+		root := math.Sqrt(weight)
+		scaledA[y] = scaledA[y].MultiplyWithNumber(root)
+		scaledB[y] *= root
+	}
+
+	if weights.Sum() == 0 {
+		return Fit{}, ErrSingular
+	}
+
+	coefficients, err := solveLeastSquares(scaledA, scaledB, method)
+	if err != nil {
+		return Fit{}, err
+	}
+
+	return diagnose(a, b, weights, coefficients), nil
+}
+
+// solveLeastSquares solves the unweighted problem with the chosen method
+func solveLeastSquares(a Matrix, b Vector, method LeastSquaresMethod) (Vector, error) {
+	switch method {
+	case LeastSquaresNormal:
+		at := a.Transpose()
+		cholesky, err := NewCholesky(Multiply(at, a))
+		if err == ErrNotPositiveDefinite {
+			return nil, ErrSingular
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return cholesky.Solve(at.multiplyVector(b))
+	case LeastSquaresPseudoInverse:
+		inverse, err := a.PseudoInverse()
+		if err != nil {
+			return nil, err
+		}
+
+		return inverse.multiplyVector(b), nil
+	}
+
+	qr, err := NewQR(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return qr.Solve(b)
+}
+
+// diagnose calculates the residuals and the coefficient of determination of the solution
+func diagnose(a Matrix, b, weights, coefficients Vector) Fit {
+	predicted := a.multiplyVector(coefficients)
+
+	mean := b.MultiplyElementByElement(weights).Sum() / weights.Sum()
+
+	residuals := ShellV(len(b))
+	rss, tss := 0.0, 0.0
+	for i := range b {
+		residuals[i] = b[i] - predicted[i]
+		rss += weights[i] * residuals[i] * residuals[i]
+		tss += weights[i] * (b[i] - mean) * (b[i] - mean)
+	}
+
+	rSquared := 1.0
+	if tss > 0 {
+		rSquared = 1 - rss/tss
+	} else if rss > Epsilon {
+		rSquared = 0
+	}
+
+	return Fit{
+		Coefficients:         coefficients,
+		Residuals:            residuals,
+		ResidualSumOfSquares: rss,
+		RSquared:             rSquared,
+	}
+}
+
+// Vandermonde generates the matrix of the powers 0..degree of every x
+func Vandermonde(x Vector, degree int) Matrix {
+	m := ShellM(degree+1, len(x))
+	for y, value := range x {
+		power := 1.0
+		for k := range m[y] {
+			m[y][k] = power
+			power *= value
+		}
+	}
+
+	return m
+}
+
+// PolynomialFit fits the polynomial of the degree to the points,
+// the coefficients are ordered from the free term to the highest power
+func PolynomialFit(x, y Vector, degree int, method LeastSquaresMethod) (Fit, error) {
+	if len(x) != len(y) || degree < 0 {
+		return Fit{}, ErrDimensionMismatch
+	}
+
+	if len(x) == 0 {
+		return Fit{}, ErrEmpty
+	}
+
+	return LeastSquares(Vandermonde(x, degree), y, method)
+}
+
+// Polynomial calculates the polynomial with the coefficients from the free term at x with Horner's method
+func Polynomial(coefficients Vector, x float64) float64 {
+	value := 0.0
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		value = value*x + coefficients[i]
+	}
+
+	return value
+}
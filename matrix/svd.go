@@ -0,0 +1,207 @@
+package matrix
+
+import "math"
+
+// SVD is the thin singular value decomposition A = U * diag(values) * V^T of m x n matrix,
+// U is m x k and V is n x k with orthonormal columns, k = min(m, n).
+// The columns of U for zero singular values complete the orthonormal basis. The singular values are sorted in descending order
+type SVD struct {
+	u      Matrix
+	values Vector
+	v      Matrix
+}
+
+// NewSVD makes the decomposition with one-sided Jacobi rotations
+func NewSVD(m Matrix) (SVD, error) {
+	if err := m.Validate(); err != nil {
+		return SVD{}, err
+	}
+
+	w, h := m.Size()
+	if h < w {
+		svd, err := NewSVD(m.Transpose())
+		if err != nil {
+			return SVD{}, err
+		}
+
+		return SVD{u: svd.v, values: svd.values, v: svd.u}, nil
+	}
+
+	u := m.Clone()
+	v := Identity(w)
+
+	// the columns of U are rotated pairwise until all of them are orthogonal
+	for sweep := 0; ; sweep++ {
+		rotated := false
+		for p := 0; p < w-1; p++ {
+			for q := p + 1; q < w; q++ {
+				columnP, columnQ := u.GetColumn(p), u.GetColumn(q)
+				alpha := columnP.MultiplyElementByElement(columnP).Sum()
+				beta := columnQ.MultiplyElementByElement(columnQ).Sum()
+				gamma := columnP.MultiplyElementByElement(columnQ).Sum()
+
+				if math.Abs(gamma) <= Epsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Hypot(zeta, 1))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Hypot(t, 1)
+				s := t * c
+
+				rotateColumns(u, p, q, c, -s)
+				rotateColumns(v, p, q, c, -s)
+			}
+		}
+
+		if !rotated {
+			break
+		}
+
+		if sweep == maxSweeps {
+			return SVD{}, ErrNotConverged
+		}
+	}
+
+	// the norms of the columns are the singular values, the columns zero within the tolerance are completed later
+	tolerance := Epsilon * math.Max(m.maxAbs(), 1)
+	values := ShellV(w)
+	for x := range values {
+		values[x] = u.GetColumn(x).norm()
+		if values[x] <= tolerance {
+			values[x] = 0
+			continue
+		}
+
+		for _, row := range u {
+			row[x] /= values[x]
+		}
+	}
+
+	values, sorted := sortColumns(values, u, v)
+	completeBasis(sorted[0], values)
+	return SVD{u: sorted[0], values: values, v: sorted[1]}, nil
+}
+
+// completeBasis replaces the columns of zero singular values with the unit vectors orthogonal
+// to the other columns. Every axis is orthogonalized with Gram-Schmidt process and the longest rest is taken
+func completeBasis(u Matrix, values Vector) {
+	h := u.Height()
+	for x, value := range values {
+		if value != 0 {
+			continue
+		}
+
+		best, bestNorm := Vector(nil), 0.0
+		for axis := 0; axis < h; axis++ {
+			column := ShellV(h)
+			column[axis] = 1
+
+			// the second pass of the orthogonalization removes the rounding errors of the first one
+			for pass := 0; pass < 2; pass++ {
+				for other := range values {
+					if other >= x && values[other] == 0 {
+						continue
+					}
+
+					product := u.GetColumn(other).MultiplyElementByElement(column).Sum()
+					for y := range column {
+						column[y] -= product * u[y][other]
+					}
+				}
+			}
+
+			if norm := column.norm(); norm > bestNorm {
+				best, bestNorm = column, norm
+			}
+		}
+
+		for y := range best {
+			u[y][x] = best[y] / bestNorm
+		}
+	}
+}
+
+// U returns the left singular vectors as the columns
+func (svd SVD) U() Matrix {
+	return svd.u.Clone()
+}
+
+// Values returns the singular values in descending order
+func (svd SVD) Values() Vector {
+	return svd.values.Clone()
+}
+
+// V returns the right singular vectors as the columns
+func (svd SVD) V() Matrix {
+	return svd.v.Clone()
+}
+
+// tolerance returns the value the singular values are treated as zeros up to
+func (svd SVD) tolerance() float64 {
+	return Epsilon * math.Max(svd.values[0], 1)
+}
+
+// Rank returns the count of the non-zero singular values
+func (svd SVD) Rank() int {
+	rank := 0
+	for _, value := range svd.values {
+		if value > svd.tolerance() {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+// Condition returns the ratio of the biggest and the smallest singular values,
+// it's infinite for rank deficient matrices
+func (svd SVD) Condition() float64 {
+	last := svd.values[len(svd.values)-1]
+	if last <= svd.tolerance() {
+		return math.Inf(1)
+	}
+
+	return svd.values[0] / last
+}
+
+// PseudoInverse returns Moore-Penrose pseudo-inverse V * diag(1 / values) * U^T,
+// zero singular values are left zeros
+func (svd SVD) PseudoInverse() Matrix {
+	scaled := svd.v.Clone()
+	for _, row := range scaled {
+		for x, value := range svd.values {
+			if value > svd.tolerance() {
+				row[x] /= value
+			} else {
+				row[x] = 0
+			}
+		}
+	}
+
+	return Multiply(scaled, svd.u.Transpose())
+}
+
+// PseudoInverse returns Moore-Penrose pseudo-inverse of the matrix of any size
+func (m Matrix) PseudoInverse() (Matrix, error) {
+	svd, err := NewSVD(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return svd.PseudoInverse(), nil
+}
+
+// Condition returns the condition number of the matrix in the euclidean norm
+func (m Matrix) Condition() (float64, error) {
+	svd, err := NewSVD(m)
+	if err != nil {
+		return 0, err
+	}
+
+	return svd.Condition(), nil
+}
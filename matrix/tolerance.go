@@ -0,0 +1,26 @@
+package matrix
+
+import "math"
+
+// Epsilon is the tolerance used to compare floats in matrix and lpt packages.
+// Pivots of the elimination are compared with Epsilon relatively to the biggest element of the matrix
+var Epsilon = 1e-12
+
+// Epsilon32 is the tolerance of Float32 numbers, it's bigger than Epsilon as single precision is lower
+var Epsilon32 = 1e-6
+
+// IsZero checks if the value is zero within Epsilon
+func IsZero(value float64) bool {
+	return math.Abs(value) <= Epsilon
+}
+
+// Equal checks if the values are equal within Epsilon, big values are compared relatively
+func Equal(a, b float64) bool {
+	return math.Abs(a-b) <= Epsilon*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// Tolerance returns Epsilon relative to the biggest element of the matrix, it's used to compare
+// the pivots of the matrix
+func (m Matrix) Tolerance() float64 {
+	return Epsilon * math.Max(m.maxAbs(), 1)
+}
@@ -0,0 +1,486 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"gomo/lpt"
+	"gomo/matrix"
+	"math"
+)
+
+var (
+	// ErrNodeOutOfRange is returned when a node index doesn't belong to the graph
+	ErrNodeOutOfRange = errors.New("network: node index is out of range")
+	// ErrSameNodes is returned when source and sink are the same node
+	ErrSameNodes = errors.New("network: source and sink must differ")
+	// ErrInsufficientCapacity is returned when requested amount can't be sent from source to sink
+	ErrInsufficientCapacity = errors.New("network: requested amount exceeds max flow")
+	// ErrNegativeCycle is returned when the residual network has a cycle of negative cost
+	ErrNegativeCycle = errors.New("network: negative cost cycle")
+	// ErrNegativeCapacity is returned when an edge has negative capacity
+	ErrNegativeCapacity = errors.New("network: negative edge capacity")
+)
+
+// Edge is a directed arc with capacity and cost per unit of flow
+type Edge struct {
+	From     int
+	To       int
+	Capacity float64
+	Cost     float64
+}
+
+// Graph is a directed network with nodes numbered from 0
+type Graph struct {
+	Nodes int
+	Edges []Edge
+}
+
+// Flow contains a flow through the network
+type Flow struct {
+	Value float64
+	Cost  float64
+	// EdgeFlows is the flow through each of Graph.Edges
+	EdgeFlows matrix.Vector
+}
+
+// Cut is a source-sink cut of the network
+type Cut struct {
+	Capacity float64
+	// SourceSide shows which nodes are reachable from the source in the residual network
+	SourceSide []bool
+	// Edges are the indexes of edges going from source side to sink side
+	Edges []int
+}
+
+// AddEdge returns the graph with a new edge appended
+func (g Graph) AddEdge(from, to int, capacity, cost float64) Graph {
+	edges := make([]Edge, len(g.Edges), len(g.Edges)+1)
+	copy(edges, g.Edges)
+
+	nodes := g.Nodes
+	if from >= nodes {
+		nodes = from + 1
+	}
+	if to >= nodes {
+		nodes = to + 1
+	}
+
+	return Graph{
+		Nodes: nodes,
+		Edges: append(edges, Edge{from, to, capacity, cost}),
+	}
+}
+
+// validate checks that every edge connects nodes of the graph and has non-negative capacity
+func (g Graph) validate() error {
+	for _, e := range g.Edges {
+		if !g.hasNode(e.From) || !g.hasNode(e.To) {
+			return ErrNodeOutOfRange
+		}
+
+		if e.Capacity < 0 {
+			return ErrNegativeCapacity
+		}
+	}
+
+	return nil
+}
+
+func (g Graph) hasNode(node int) bool {
+	return node >= 0 && node < g.Nodes
+}
+
+func (g Graph) checkSource(source int) error {
+	if !g.hasNode(source) {
+		return ErrNodeOutOfRange
+	}
+
+	return g.validate()
+}
+
+func (g Graph) checkTerminals(source, sink int) error {
+	if err := g.checkSource(source); err != nil {
+		return err
+	}
+
+	if !g.hasNode(sink) {
+		return ErrNodeOutOfRange
+	}
+
+	if source == sink {
+		return ErrSameNodes
+	}
+
+	return nil
+}
+
+// arc is an arc of the residual network, arcs 2i and 2i+1 are the forward and backward arcs of edge i
+type arc struct {
+	to       int
+	residual float64
+	cost     float64
+}
+
+type residualNetwork struct {
+	arcs []arc
+	// adjacent holds indexes of arcs leaving each node
+	adjacent [][]int
+}
+
+func (g Graph) residual() residualNetwork {
+	r := residualNetwork{
+		arcs:     make([]arc, 0, 2*len(g.Edges)),
+		adjacent: make([][]int, g.Nodes),
+	}
+
+	for i, e := range g.Edges {
+		r.arcs = append(r.arcs, arc{e.To, e.Capacity, e.Cost}, arc{e.From, 0, -e.Cost})
+		r.adjacent[e.From] = append(r.adjacent[e.From], 2*i)
+		r.adjacent[e.To] = append(r.adjacent[e.To], 2*i+1)
+	}
+
+	return r
+}
+
+// push sends amount along the path given as arc indexes
+func (r residualNetwork) push(path []int, amount float64) {
+	for _, a := range path {
+		r.arcs[a].residual -= amount
+		r.arcs[a^1].residual += amount
+	}
+}
+
+// bottleneck returns minimal residual capacity of the path
+func (r residualNetwork) bottleneck(path []int) float64 {
+	min := math.Inf(1)
+	for _, a := range path {
+		min = math.Min(min, r.arcs[a].residual)
+	}
+
+	return min
+}
+
+// shortestPath finds a path with the fewest arcs from source to sink (breadth-first search)
+func (r residualNetwork) shortestPath(source, sink int) []int {
+	via := make([]int, len(r.adjacent))
+	for i := range via {
+		via[i] = -1
+	}
+
+	visited := make([]bool, len(r.adjacent))
+	visited[source] = true
+	queue := []int{source}
+	for len(queue) > 0 && !visited[sink] {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, a := range r.adjacent[node] {
+			next := r.arcs[a].to
+			if !visited[next] && r.arcs[a].residual > matrix.Epsilon {
+				visited[next] = true
+				via[next] = a
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if !visited[sink] {
+		return nil
+	}
+
+	return r.path(via, sink)
+}
+
+// cheapestPath finds a path of minimal cost from source to sink (Bellman-Ford)
+func (r residualNetwork) cheapestPath(source, sink int) ([]int, error) {
+	n := len(r.adjacent)
+	distance := matrix.ShellVWithValue(n, math.Inf(1))
+	via := make([]int, n)
+	for i := range via {
+		via[i] = -1
+	}
+
+	distance[source] = 0
+	for i := 0; i < n; i++ {
+		changed := false
+		for node, arcs := range r.adjacent {
+			if math.IsInf(distance[node], 1) {
+				continue
+			}
+
+			for _, a := range arcs {
+				next := r.arcs[a].to
+				if r.arcs[a].residual > matrix.Epsilon && distance[node]+r.arcs[a].cost < distance[next]-matrix.Epsilon {
+					distance[next] = distance[node] + r.arcs[a].cost
+					via[next] = a
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			break
+		}
+
+		if i == n-1 {
+			return nil, ErrNegativeCycle
+		}
+	}
+
+	if math.IsInf(distance[sink], 1) {
+		return nil, nil
+	}
+
+	return r.path(via, sink), nil
+}
+
+// path restores the path to the node from arcs the nodes were reached with
+func (r residualNetwork) path(via []int, node int) []int {
+	path := []int{}
+	for via[node] != -1 {
+		a := via[node]
+		path = append([]int{a}, path...)
+		node = r.arcs[a^1].to
+	}
+
+	return path
+}
+
+func (g Graph) flowOf(r residualNetwork) Flow {
+	edgeFlows := matrix.ShellV(len(g.Edges))
+	cost := 0.0
+	for i, e := range g.Edges {
+		edgeFlows[i] = r.arcs[2*i+1].residual
+		cost += edgeFlows[i] * e.Cost
+	}
+
+	return Flow{
+		EdgeFlows: edgeFlows,
+		Cost:      cost,
+	}
+}
+
+// MaxFlow finds maximal flow from source to sink (Edmonds-Karp)
+func (g Graph) MaxFlow(source, sink int) (Flow, error) {
+	if err := g.checkTerminals(source, sink); err != nil {
+		return Flow{}, err
+	}
+
+	r := g.residual()
+	value := 0.0
+	for path := r.shortestPath(source, sink); path != nil; path = r.shortestPath(source, sink) {
+		amount := r.bottleneck(path)
+		if math.IsInf(amount, 1) {
+			return Flow{}, ErrInsufficientCapacity
+		}
+
+		r.push(path, amount)
+		value += amount
+	}
+
+	flow := g.flowOf(r)
+	flow.Value = value
+
+	return flow, nil
+}
+
+// MinCut finds the cut of minimal capacity separating source from sink
+func (g Graph) MinCut(source, sink int) (Cut, error) {
+	flow, err := g.MaxFlow(source, sink)
+	if err != nil {
+		return Cut{}, err
+	}
+
+	// nodes reachable from the source through unsaturated arcs
+	r := g.residual()
+	for i := range g.Edges {
+		r.push([]int{2 * i}, flow.EdgeFlows[i])
+	}
+
+	sourceSide := make([]bool, g.Nodes)
+	sourceSide[source] = true
+	queue := []int{source}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, a := range r.adjacent[node] {
+			next := r.arcs[a].to
+			if !sourceSide[next] && r.arcs[a].residual > matrix.Epsilon {
+				sourceSide[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	cut := Cut{SourceSide: sourceSide}
+	for i, e := range g.Edges {
+		if sourceSide[e.From] && !sourceSide[e.To] {
+			cut.Edges = append(cut.Edges, i)
+			cut.Capacity += e.Capacity
+		}
+	}
+
+	return cut, nil
+}
+
+// MinCostFlow sends amount from source to sink with minimal total cost (successive shortest paths).
+// Pass math.Inf(1) as amount to find minimal cost maximal flow
+func (g Graph) MinCostFlow(source, sink int, amount float64) (Flow, error) {
+	if err := g.checkTerminals(source, sink); err != nil {
+		return Flow{}, err
+	}
+
+	r := g.residual()
+	value := 0.0
+	for value < amount-matrix.Epsilon {
+		path, err := r.cheapestPath(source, sink)
+		if err != nil {
+			return Flow{}, err
+		}
+
+		if path == nil {
+			if math.IsInf(amount, 1) {
+				break
+			}
+
+			return Flow{}, ErrInsufficientCapacity
+		}
+
+		delta := math.Min(r.bottleneck(path), amount-value)
+		if math.IsInf(delta, 1) {
+			return Flow{}, ErrInsufficientCapacity
+		}
+
+		r.push(path, delta)
+		value += delta
+	}
+
+	flow := g.flowOf(r)
+	flow.Value = value
+
+	return flow, nil
+}
+
+// balanceMatrix returns flow conservation limitations for every node except skipped ones
+// and capacity limitations for every edge of finite capacity
+func (g Graph) balanceMatrix(balance matrix.Vector, skip []bool) (matrix.Matrix, []lpt.Operator) {
+	w := len(g.Edges)
+	m := matrix.Matrix{}
+	operators := []lpt.Operator{}
+
+	for node := 0; node < g.Nodes; node++ {
+		if skip[node] {
+			continue
+		}
+
+		row := matrix.ShellV(w + 1)
+		for i, e := range g.Edges {
+			if e.From == node {
+				row[i]++
+			}
+			if e.To == node {
+				row[i]--
+			}
+		}
+		row[w] = balance[node]
+
+		m = append(m, row)
+		operators = append(operators, lpt.OperatorEqual)
+	}
+
+	for i, e := range g.Edges {
+		if math.IsInf(e.Capacity, 1) {
+			continue
+		}
+
+		row := matrix.ShellV(w + 1)
+		row[i] = 1
+		row[w] = e.Capacity
+
+		m = append(m, row)
+		operators = append(operators, lpt.OperatorLessOrEqual)
+	}
+
+	return m, operators
+}
+
+// MaxFlowLPT generates LPT equal to the max flow problem, x_i is the flow through i-th edge
+func (g Graph) MaxFlowLPT(source, sink int) lpt.LPT {
+	skip := make([]bool, g.Nodes)
+	skip[source] = true
+	skip[sink] = true
+
+	m, operators := g.balanceMatrix(matrix.ShellV(g.Nodes), skip)
+
+	coeffs := matrix.ShellV(len(g.Edges))
+	for i, e := range g.Edges {
+		if e.From == source {
+			coeffs[i]++
+		}
+		if e.To == source {
+			coeffs[i]--
+		}
+	}
+
+	return lpt.LPT{}.
+		SetMatrix(m, operators).
+		SetSignConditionToEvery(lpt.OperatorGreaterOrEqual).
+		SetTargetCoeffs(coeffs, lpt.BoundMax)
+}
+
+// MinCostFlowLPT generates LPT equal to the problem of sending amount from source to sink with minimal cost
+func (g Graph) MinCostFlowLPT(source, sink int, amount float64) lpt.LPT {
+	balance := matrix.ShellV(g.Nodes)
+	balance[source] = amount
+	balance[sink] = -amount
+
+	// conservation at the sink follows from the others
+	skip := make([]bool, g.Nodes)
+	skip[sink] = true
+
+	m, operators := g.balanceMatrix(balance, skip)
+
+	coeffs := matrix.ShellV(len(g.Edges))
+	for i, e := range g.Edges {
+		coeffs[i] = e.Cost
+	}
+
+	return lpt.LPT{}.
+		SetMatrix(m, operators).
+		SetSignConditionToEvery(lpt.OperatorGreaterOrEqual).
+		SetTargetCoeffs(coeffs, lpt.BoundMin)
+}
+
+func (e Edge) String() string {
+	return fmt.Sprintf("%d -> %d (capacity: %s, cost: %s)", e.From+1, e.To+1, matrix.HumaniazeValue(e.Capacity), matrix.HumaniazeValue(e.Cost))
+}
+
+func (g Graph) String() string {
+	str := ""
+	for _, e := range g.Edges {
+		str += e.String() + "\n"
+	}
+
+	return str
+}
+
+func (f Flow) String() string {
+	return fmt.Sprintf("flows:\t[%s]\nvalue:\t%f\ncost:\t%f", f.EdgeFlows, f.Value, f.Cost)
+}
+
+func (c Cut) String() string {
+	str := "source side:"
+	for node, isSource := range c.SourceSide {
+		if isSource {
+			str += fmt.Sprintf(" %d", node+1)
+		}
+	}
+
+	str += "\nedges:"
+	for _, i := range c.Edges {
+		str += fmt.Sprintf(" %d", i+1)
+	}
+
+	return str + fmt.Sprintf("\ncapacity:\t%f", c.Capacity)
+}
@@ -0,0 +1,207 @@
+package network
+
+import (
+	"gomo/lpt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// classic example from CLRS: max flow is 23
+func clrsGraph() Graph {
+	return Graph{}.
+		AddEdge(0, 1, 16, 0).
+		AddEdge(0, 2, 13, 0).
+		AddEdge(1, 3, 12, 0).
+		AddEdge(2, 1, 4, 0).
+		AddEdge(2, 4, 14, 0).
+		AddEdge(3, 2, 9, 0).
+		AddEdge(3, 5, 20, 0).
+		AddEdge(4, 3, 7, 0).
+		AddEdge(4, 5, 4, 0)
+}
+
+func TestMaxFlow(t *testing.T) {
+	flow, err := clrsGraph().MaxFlow(0, 5)
+	if err != nil {
+		t.Fatalf("MaxFlow() error = %v", err)
+	}
+	if flow.Value != 23 {
+		t.Errorf("MaxFlow() value = %v, want 23", flow.Value)
+	}
+}
+
+func TestMinCut(t *testing.T) {
+	cut, err := clrsGraph().MinCut(0, 5)
+	if err != nil {
+		t.Fatalf("MinCut() error = %v", err)
+	}
+	if cut.Capacity != 23 {
+		t.Errorf("MinCut() capacity = %v, want 23", cut.Capacity)
+	}
+	if !cut.SourceSide[0] || cut.SourceSide[5] {
+		t.Errorf("MinCut() source side = %v", cut.SourceSide)
+	}
+}
+
+// max flow from 0 to 3 is 6 with cost 24
+func costGraph() Graph {
+	return Graph{}.
+		AddEdge(0, 1, 4, 2).
+		AddEdge(0, 2, 2, 2).
+		AddEdge(1, 2, 2, 1).
+		AddEdge(1, 3, 3, 3).
+		AddEdge(2, 3, 5, 1)
+}
+
+func TestMinCostFlow(t *testing.T) {
+	g := costGraph()
+
+	tests := []struct {
+		name      string
+		amount    float64
+		wantValue float64
+		wantCost  float64
+		wantErr   error
+	}{
+		{"partial", 4, 4, 14, nil},
+		{"max", math.Inf(1), 6, 24, nil},
+		{"too much", 7, 0, 0, ErrInsufficientCapacity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flow, err := g.MinCostFlow(0, 3, tt.amount)
+			if err != tt.wantErr {
+				t.Fatalf("MinCostFlow() error = %v, want %v", err, tt.wantErr)
+			}
+			if flow.Value != tt.wantValue || flow.Cost != tt.wantCost {
+				t.Errorf("MinCostFlow() = (%v, %v), want (%v, %v)", flow.Value, flow.Cost, tt.wantValue, tt.wantCost)
+			}
+		})
+	}
+}
+
+// solve returns the optimum of the task found by the project's simplex method
+func solve(t *testing.T, task lpt.LPT) float64 {
+	_, value, err := task.Solve()
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+
+	return value
+}
+
+func TestMaxFlowLPT(t *testing.T) {
+	tests := []struct {
+		name   string
+		g      Graph
+		source int
+		sink   int
+	}{
+		{"clrs", clrsGraph(), 0, 5},
+		{"costs", costGraph(), 0, 3},
+		// the edges around the source and the sink aren't in any balance row
+		{"direct", Graph{}.
+			AddEdge(0, 2, 3, 0).
+			AddEdge(2, 0, 2, 0).
+			AddEdge(0, 1, 2, 0).
+			AddEdge(1, 2, 5, 0).
+			AddEdge(1, 0, 1, 0), 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flow, err := tt.g.MaxFlow(tt.source, tt.sink)
+			if err != nil {
+				t.Fatalf("MaxFlow() error = %v", err)
+			}
+
+			if got := solve(t, tt.g.MaxFlowLPT(tt.source, tt.sink)); math.Abs(got-flow.Value) > 1e-9 {
+				t.Errorf("MaxFlowLPT() optimum = %v, MaxFlow() = %v", got, flow.Value)
+			}
+		})
+	}
+}
+
+func TestMinCostFlowLPT(t *testing.T) {
+	g := costGraph()
+	for _, amount := range []float64{1, 2, 3, 4, 5, 6} {
+		flow, err := g.MinCostFlow(0, 3, amount)
+		if err != nil {
+			t.Fatalf("MinCostFlow(%v) error = %v", amount, err)
+		}
+
+		if got := solve(t, g.MinCostFlowLPT(0, 3, amount)); math.Abs(got-flow.Cost) > 1e-9 {
+			t.Errorf("MinCostFlowLPT(%v) optimum = %v, MinCostFlow() = %v", amount, got, flow.Cost)
+		}
+	}
+}
+
+func TestMaxFlowErrors(t *testing.T) {
+	g := clrsGraph()
+	if _, err := g.MaxFlow(0, 0); err != ErrSameNodes {
+		t.Errorf("MaxFlow() error = %v, want %v", err, ErrSameNodes)
+	}
+	if _, err := g.MaxFlow(0, 6); err != ErrNodeOutOfRange {
+		t.Errorf("MaxFlow() error = %v, want %v", err, ErrNodeOutOfRange)
+	}
+
+	outside := Graph{Nodes: 2, Edges: []Edge{{0, 1, 1, 0}, {1, 2, 1, 0}}}
+	if _, err := outside.MaxFlow(0, 1); err != ErrNodeOutOfRange {
+		t.Errorf("MaxFlow() error = %v, want %v", err, ErrNodeOutOfRange)
+	}
+	if _, err := outside.Dijkstra(0); err != ErrNodeOutOfRange {
+		t.Errorf("Dijkstra() error = %v, want %v", err, ErrNodeOutOfRange)
+	}
+
+	negative := g.AddEdge(1, 2, -1, 0)
+	if _, err := negative.MaxFlow(0, 5); err != ErrNegativeCapacity {
+		t.Errorf("MaxFlow() error = %v, want %v", err, ErrNegativeCapacity)
+	}
+	if _, err := negative.MinCostFlow(0, 5, 1); err != ErrNegativeCapacity {
+		t.Errorf("MinCostFlow() error = %v, want %v", err, ErrNegativeCapacity)
+	}
+}
+
+func TestShortestPaths(t *testing.T) {
+	g := Graph{}.
+		AddEdge(0, 1, 0, 4).
+		AddEdge(0, 2, 0, 1).
+		AddEdge(2, 1, 0, 2).
+		AddEdge(1, 3, 0, 1).
+		AddEdge(2, 3, 0, 5)
+
+	for name, find := range map[string]func(int) (Paths, error){"Dijkstra": g.Dijkstra, "BellmanFord": g.BellmanFord} {
+		t.Run(name, func(t *testing.T) {
+			paths, err := find(0)
+			if err != nil {
+				t.Fatalf("%s() error = %v", name, err)
+			}
+			if paths.Distances[3] != 4 {
+				t.Errorf("%s() distance = %v, want 4", name, paths.Distances[3])
+			}
+			if got := paths.PathTo(3); !reflect.DeepEqual(got, []int{0, 2, 1, 3}) {
+				t.Errorf("%s() path = %v, want [0 2 1 3]", name, got)
+			}
+		})
+	}
+}
+
+func TestBellmanFordNegative(t *testing.T) {
+	g := Graph{}.
+		AddEdge(0, 1, 0, 4).
+		AddEdge(1, 2, 0, -3).
+		AddEdge(0, 2, 0, 2)
+
+	if _, err := g.Dijkstra(0); err != ErrNegativeCost {
+		t.Errorf("Dijkstra() error = %v, want %v", err, ErrNegativeCost)
+	}
+
+	paths, err := g.BellmanFord(0)
+	if err != nil || paths.Distances[2] != 1 {
+		t.Errorf("BellmanFord() = %v, %v, want distance 1", paths.Distances, err)
+	}
+
+	if _, err := g.AddEdge(2, 1, 0, 1).BellmanFord(0); err != ErrNegativeCycle {
+		t.Errorf("BellmanFord() error = %v, want %v", err, ErrNegativeCycle)
+	}
+}
@@ -0,0 +1,148 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"gomo/matrix"
+	"math"
+)
+
+// ErrNegativeCost is returned by Dijkstra when the graph has an edge of negative cost
+var ErrNegativeCost = errors.New("network: negative edge cost")
+
+// Paths contains shortest paths from the source to every node, edge costs are used as lengths
+type Paths struct {
+	Source int
+	// Distances is +Inf for unreachable nodes
+	Distances matrix.Vector
+	// Previous is the index of the last edge of the path to each node (-1 if none)
+	Previous []int
+	edges    []Edge
+}
+
+func (g Graph) shellPaths(source int) Paths {
+	previous := make([]int, g.Nodes)
+	for i := range previous {
+		previous[i] = -1
+	}
+
+	distances := matrix.ShellVWithValue(g.Nodes, math.Inf(1))
+	distances[source] = 0
+
+	return Paths{
+		Source:    source,
+		Distances: distances,
+		Previous:  previous,
+		edges:     g.Edges,
+	}
+}
+
+// Dijkstra finds shortest paths from the source, every edge cost must be non-negative
+func (g Graph) Dijkstra(source int) (Paths, error) {
+	if err := g.checkSource(source); err != nil {
+		return Paths{}, err
+	}
+
+	adjacent := make([][]int, g.Nodes)
+	for i, e := range g.Edges {
+		if e.Cost < 0 {
+			return Paths{}, ErrNegativeCost
+		}
+
+		adjacent[e.From] = append(adjacent[e.From], i)
+	}
+
+	paths := g.shellPaths(source)
+	done := make([]bool, g.Nodes)
+	for {
+		node := -1
+		for i, distance := range paths.Distances {
+			if !done[i] && !math.IsInf(distance, 1) && (node == -1 || distance < paths.Distances[node]) {
+				node = i
+			}
+		}
+
+		if node == -1 {
+			break
+		}
+
+		done[node] = true
+		for _, i := range adjacent[node] {
+			e := g.Edges[i]
+			if distance := paths.Distances[node] + e.Cost; distance < paths.Distances[e.To] {
+				paths.Distances[e.To] = distance
+				paths.Previous[e.To] = i
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// BellmanFord finds shortest paths from the source allowing negative edge costs
+func (g Graph) BellmanFord(source int) (Paths, error) {
+	if err := g.checkSource(source); err != nil {
+		return Paths{}, err
+	}
+
+	paths := g.shellPaths(source)
+	for i := 0; i < g.Nodes; i++ {
+		changed := false
+		for index, e := range g.Edges {
+			if math.IsInf(paths.Distances[e.From], 1) {
+				continue
+			}
+
+			if distance := paths.Distances[e.From] + e.Cost; distance < paths.Distances[e.To] {
+				paths.Distances[e.To] = distance
+				paths.Previous[e.To] = index
+				changed = true
+			}
+		}
+
+		if !changed {
+			return paths, nil
+		}
+	}
+
+	return Paths{}, ErrNegativeCycle
+}
+
+// PathTo returns nodes of the shortest path from the source to the node, nil if the node is unreachable
+func (p Paths) PathTo(node int) []int {
+	if math.IsInf(p.Distances[node], 1) {
+		return nil
+	}
+
+	path := []int{node}
+	for node != p.Source {
+		node = p.edges[p.Previous[node]].From
+		path = append([]int{node}, path...)
+	}
+
+	return path
+}
+
+func (p Paths) String() string {
+	str := ""
+	for node, distance := range p.Distances {
+		str += fmt.Sprintf("%d -> %d:\t", p.Source+1, node+1)
+
+		path := p.PathTo(node)
+		if path == nil {
+			str += "unreachable\n"
+			continue
+		}
+
+		for i, n := range path {
+			if i != 0 {
+				str += " - "
+			}
+			str += fmt.Sprintf("%d", n+1)
+		}
+
+		str += fmt.Sprintf(" (%s)\n", matrix.HumaniazeValue(distance))
+	}
+
+	return str
+}
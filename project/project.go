@@ -0,0 +1,253 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"gomo/matrix"
+	"math"
+)
+
+var (
+	// ErrUnknownTask is returned when a predecessor isn't in the task list
+	ErrUnknownTask = errors.New("project: unknown predecessor")
+	// ErrDuplicateTask is returned when two tasks have the same name
+	ErrDuplicateTask = errors.New("project: duplicate task name")
+	// ErrCycle is returned when tasks' precedence has a cycle
+	ErrCycle = errors.New("project: precedence has a cycle")
+)
+
+// Task is a project task. If Optimistic and Pessimistic are set,
+// Duration is treated as the most likely PERT estimate
+type Task struct {
+	Name         string
+	Duration     float64
+	Optimistic   float64
+	Pessimistic  float64
+	Predecessors []string
+}
+
+// Schedule contains results of the critical path analysis, vectors are indexed like tasks
+type Schedule struct {
+	Tasks       []Task
+	Durations   matrix.Vector
+	Variances   matrix.Vector
+	EarlyStart  matrix.Vector
+	EarlyFinish matrix.Vector
+	LateStart   matrix.Vector
+	LateFinish  matrix.Vector
+	Slack       matrix.Vector
+	// CriticalPath holds indexes of critical tasks in order of execution
+	CriticalPath []int
+	// Duration and Variance are expected duration of the project and its variance
+	Duration float64
+	Variance float64
+}
+
+// Expected returns expected duration of the task: (a + 4m + b) / 6 for PERT estimates
+func (t Task) Expected() float64 {
+	if t.Optimistic == 0 && t.Pessimistic == 0 {
+		return t.Duration
+	}
+
+	return (t.Optimistic + 4*t.Duration + t.Pessimistic) / 6
+}
+
+// Variance returns variance of the task duration: ((b - a) / 6)^2 for PERT estimates
+func (t Task) Variance() float64 {
+	if t.Optimistic == 0 && t.Pessimistic == 0 {
+		return 0
+	}
+
+	deviation := (t.Pessimistic - t.Optimistic) / 6
+	return deviation * deviation
+}
+
+// order returns predecessors' indexes of every task and the tasks' topological order
+func order(tasks []Task) ([][]int, []int, error) {
+	indexes := map[string]int{}
+	for i, task := range tasks {
+		if _, ok := indexes[task.Name]; ok {
+			return nil, nil, ErrDuplicateTask
+		}
+
+		indexes[task.Name] = i
+	}
+
+	predecessors := make([][]int, len(tasks))
+	waiting := make([]int, len(tasks))
+	for i, task := range tasks {
+		for _, name := range task.Predecessors {
+			p, ok := indexes[name]
+			if !ok {
+				return nil, nil, ErrUnknownTask
+			}
+
+			predecessors[i] = append(predecessors[i], p)
+		}
+
+		waiting[i] = len(predecessors[i])
+	}
+
+	successors := make([][]int, len(tasks))
+	for i, ps := range predecessors {
+		for _, p := range ps {
+			successors[p] = append(successors[p], i)
+		}
+	}
+
+	sorted := []int{}
+	for i, count := range waiting {
+		if count == 0 {
+			sorted = append(sorted, i)
+		}
+	}
+
+	for i := 0; i < len(sorted); i++ {
+		for _, s := range successors[sorted[i]] {
+			waiting[s]--
+			if waiting[s] == 0 {
+				sorted = append(sorted, s)
+			}
+		}
+	}
+
+	if len(sorted) != len(tasks) {
+		return nil, nil, ErrCycle
+	}
+
+	return predecessors, sorted, nil
+}
+
+// Analyze calculates early and late times, slack and the critical path of the project (CPM/PERT)
+func Analyze(tasks []Task) (Schedule, error) {
+	predecessors, sorted, err := order(tasks)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	n := len(tasks)
+	s := Schedule{
+		Tasks:       tasks,
+		Durations:   matrix.ShellV(n),
+		Variances:   matrix.ShellV(n),
+		EarlyStart:  matrix.ShellV(n),
+		EarlyFinish: matrix.ShellV(n),
+		LateStart:   matrix.ShellV(n),
+		LateFinish:  matrix.ShellV(n),
+		Slack:       matrix.ShellV(n),
+	}
+
+	for i, task := range tasks {
+		s.Durations[i] = task.Expected()
+		s.Variances[i] = task.Variance()
+	}
+
+	// forward pass
+	for _, i := range sorted {
+		for _, p := range predecessors[i] {
+			s.EarlyStart[i] = math.Max(s.EarlyStart[i], s.EarlyFinish[p])
+		}
+
+		s.EarlyFinish[i] = s.EarlyStart[i] + s.Durations[i]
+		s.Duration = math.Max(s.Duration, s.EarlyFinish[i])
+	}
+
+	// backward pass
+	for i := range s.LateFinish {
+		s.LateFinish[i] = s.Duration
+	}
+
+	for k := n - 1; k >= 0; k-- {
+		i := sorted[k]
+		s.LateStart[i] = s.LateFinish[i] - s.Durations[i]
+		s.Slack[i] = s.LateStart[i] - s.EarlyStart[i]
+
+		for _, p := range predecessors[i] {
+			s.LateFinish[p] = math.Min(s.LateFinish[p], s.LateStart[i])
+		}
+	}
+
+	s.CriticalPath, s.Variance = criticalPath(s, predecessors, sorted)
+
+	return s, nil
+}
+
+// criticalPath finds the chain of critical tasks with the biggest variance
+func criticalPath(s Schedule, predecessors [][]int, sorted []int) ([]int, float64) {
+	n := len(sorted)
+	variance := matrix.ShellVWithValue(n, -1)
+	previous := make([]int, n)
+
+	last := -1
+	for _, i := range sorted {
+		previous[i] = -1
+		if math.Abs(s.Slack[i]) > matrix.Epsilon {
+			continue
+		}
+
+		if math.Abs(s.EarlyStart[i]) <= matrix.Epsilon {
+			variance[i] = s.Variances[i]
+		}
+
+		for _, p := range predecessors[i] {
+			if variance[p] < 0 || math.Abs(s.EarlyFinish[p]-s.EarlyStart[i]) > matrix.Epsilon {
+				continue
+			}
+
+			if v := variance[p] + s.Variances[i]; v > variance[i] {
+				variance[i] = v
+				previous[i] = p
+			}
+		}
+
+		if math.Abs(s.EarlyFinish[i]-s.Duration) <= matrix.Epsilon && variance[i] >= 0 && (last == -1 || variance[i] > variance[last]) {
+			last = i
+		}
+	}
+
+	if last == -1 {
+		return nil, 0
+	}
+
+	path := []int{}
+	for i := last; i != -1; i = previous[i] {
+		path = append([]int{i}, path...)
+	}
+
+	return path, variance[last]
+}
+
+// Probability returns probability to complete the project before the deadline
+// assuming normally distributed duration of the critical path
+func (s Schedule) Probability(deadline float64) float64 {
+	if s.Variance == 0 {
+		if deadline >= s.Duration {
+			return 1
+		}
+
+		return 0
+	}
+
+	z := (deadline - s.Duration) / math.Sqrt(s.Variance)
+	return (1 + math.Erf(z/math.Sqrt2)) / 2
+}
+
+// Table returns schedule as Matrix with columns: duration, ES, EF, LS, LF, slack
+func (s Schedule) Table() matrix.Matrix {
+	m := matrix.Matrix{s.Durations, s.EarlyStart, s.EarlyFinish, s.LateStart, s.LateFinish, s.Slack}
+	return m.Transpose()
+}
+
+func (s Schedule) String() string {
+	str := fmt.Sprintf("%-8s %6s %6s %6s %6s %6s %6s\n", "task", "t", "ES", "EF", "LS", "LF", "R")
+	for i, row := range s.Table() {
+		str += fmt.Sprintf("%-8s %s\n", s.Tasks[i].Name, row)
+	}
+
+	str += "critical path:"
+	for _, i := range s.CriticalPath {
+		str += " " + s.Tasks[i].Name
+	}
+
+	return str + fmt.Sprintf("\nduration:\t%f\nvariance:\t%f", s.Duration, s.Variance)
+}
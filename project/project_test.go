@@ -0,0 +1,82 @@
+package project
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tasks := []Task{
+		{Name: "A", Duration: 3},
+		{Name: "B", Duration: 2},
+		{Name: "C", Duration: 4, Predecessors: []string{"A"}},
+		{Name: "D", Duration: 6, Predecessors: []string{"A", "B"}},
+		{Name: "E", Duration: 2, Predecessors: []string{"C"}},
+		{Name: "F", Duration: 1, Predecessors: []string{"D", "E"}},
+	}
+
+	s, err := Analyze(tasks)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if s.Duration != 10 {
+		t.Errorf("Analyze() duration = %v, want 10", s.Duration)
+	}
+	if want := []int{0, 3, 5}; !reflect.DeepEqual(s.CriticalPath, want) {
+		t.Errorf("Analyze() critical path = %v, want %v", s.CriticalPath, want)
+	}
+	if want := []float64{0, 1, 0, 0, 0, 0}; !reflect.DeepEqual([]float64(s.Slack), want) {
+		t.Errorf("Analyze() slack = %v, want %v", s.Slack, want)
+	}
+}
+
+func TestProbability(t *testing.T) {
+	tasks := []Task{
+		{Name: "A", Optimistic: 2, Duration: 4, Pessimistic: 12},
+		{Name: "B", Optimistic: 1, Duration: 2, Pessimistic: 3, Predecessors: []string{"A"}},
+	}
+
+	s, err := Analyze(tasks)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if s.Duration != 7 {
+		t.Errorf("Analyze() duration = %v, want 7", s.Duration)
+	}
+	if got := s.Probability(s.Duration); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("Probability() = %v, want 0.5", got)
+	}
+	if got := s.Probability(s.Duration + math.Sqrt(s.Variance)); math.Abs(got-0.8413) > 1e-4 {
+		t.Errorf("Probability() = %v, want 0.8413", got)
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  error
+	}{
+		{"cycle", []Task{
+			{Name: "A", Duration: 1, Predecessors: []string{"B"}},
+			{Name: "B", Duration: 1, Predecessors: []string{"A"}},
+		}, ErrCycle},
+		{"unknown", []Task{
+			{Name: "A", Duration: 1, Predecessors: []string{"Z"}},
+		}, ErrUnknownTask},
+		{"duplicate", []Task{
+			{Name: "A", Duration: 1},
+			{Name: "A", Duration: 2},
+		}, ErrDuplicateTask},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Analyze(tt.tasks); err != tt.want {
+				t.Errorf("Analyze() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package scripts
+
+import (
+	"gomo/assignment"
+	"gomo/lpt"
+	"gomo/matrix"
+)
+
+// AssignmentScript AssignmentScript
+func AssignmentScript() {
+	costs := matrix.Matrix{
+		{9, 2, 7, 8},
+		{6, 4, 3, 7},
+		{5, 8, 1, 8},
+		{7, 6, 9, 4},
+	}
+
+	solution, err := assignment.Solve(costs, lpt.BoundMin)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, step := range solution.Steps {
+		println(step.String())
+	}
+
+	println(solution.String())
+}
@@ -0,0 +1,35 @@
+package scripts
+
+import (
+	"gomo/cooperative"
+	"gomo/matrix"
+)
+
+// CooperativeScript CooperativeScript
+func CooperativeScript() {
+	// worths of coalitions {}, {1}, {2}, {1, 2}, {3}, {1, 3}, {2, 3}, {1, 2, 3}
+	g, err := cooperative.NewGame(3, matrix.Vector{0, 1, 2, 4, 0, 3, 4, 8})
+	if err != nil {
+		panic(err)
+	}
+
+	println("Shapley value:")
+	println(g.Shapley().String())
+	println("Banzhaf index:")
+	println(g.NormalizedBanzhaf().String())
+
+	core, err := g.Core()
+	if err != nil {
+		panic(err)
+	}
+
+	println(core.String())
+
+	nucleolus, err := g.Nucleolus()
+	if err != nil {
+		panic(err)
+	}
+
+	println("Nucleolus:")
+	println(nucleolus.String())
+}
@@ -0,0 +1,36 @@
+package scripts
+
+import (
+	"gomo/decision"
+	"gomo/matrix"
+)
+
+// DecisionScript DecisionScript
+func DecisionScript() {
+	m := matrix.Matrix{
+		{20, 30, 15},
+		{75, 20, 35},
+		{25, 80, 25},
+		{85, 5, 45},
+	}
+	probabilities := matrix.Vector{0.5, 0.3, 0.2}
+
+	criteria := []func() (decision.Result, error){
+		func() (decision.Result, error) { return decision.Wald(m) },
+		func() (decision.Result, error) { return decision.Savage(m) },
+		func() (decision.Result, error) { return decision.Hurwicz(m, 0.5) },
+		func() (decision.Result, error) { return decision.Laplace(m) },
+		func() (decision.Result, error) { return decision.Bayes(m, probabilities) },
+		func() (decision.Result, error) { return decision.HodgesLehmann(m, probabilities, 0.5) },
+	}
+
+	for _, criterion := range criteria {
+		result, err := criterion()
+		if err != nil {
+			panic(err)
+		}
+
+		println(result.String())
+		println()
+	}
+}
@@ -0,0 +1,58 @@
+package scripts
+
+import (
+	"gomo/dynamic"
+	"gomo/matrix"
+	"math"
+)
+
+// KnapsackScript KnapsackScript
+func KnapsackScript() {
+	items := []dynamic.Item{
+		{Name: "a", Weight: 5, Value: 10, Count: 2},
+		{Name: "b", Weight: 4, Value: 40, Count: 1},
+		{Name: "c", Weight: 6, Value: 30, Count: 1},
+		{Name: "d", Weight: 3, Value: 50, Count: 2},
+	}
+
+	solution, err := dynamic.KnapsackBounded(items, 10)
+	if err != nil {
+		panic(err)
+	}
+
+	println(solution.String())
+}
+
+// AllocationScript AllocationScript
+func AllocationScript() {
+	profits := matrix.Matrix{
+		{0, 4, 6, 7, 8},
+		{0, 2, 5, 8, 9},
+		{0, 3, 4, 5, 6},
+	}
+
+	solution, err := dynamic.Allocate(profits, 4)
+	if err != nil {
+		panic(err)
+	}
+
+	println(solution.String())
+}
+
+// ShortestRouteScript ShortestRouteScript
+func ShortestRouteScript() {
+	inf := math.Inf(1)
+	stages := []matrix.Matrix{
+		{{2, 4, 3}},
+		{{7, 4, 6}, {3, 2, 4}, {4, 1, 5}},
+		{{1, 4}, {6, 3}, {3, 3}},
+		{{3}, {inf}},
+	}
+
+	solution, err := dynamic.ShortestRoute(stages)
+	if err != nil {
+		panic(err)
+	}
+
+	println(solution.String())
+}
@@ -0,0 +1,83 @@
+package scripts
+
+import (
+	"gomo/extensive"
+	"gomo/game"
+)
+
+// ExtensiveScript ExtensiveScript
+func ExtensiveScript() {
+	root := extensive.Decision("entrant", 0, "", []string{"out", "in"},
+		extensive.Terminal(0, 2),
+		extensive.Decision("incumbent", 1, "", []string{"fight", "accommodate"},
+			extensive.Terminal(-1, -1),
+			extensive.Terminal(1, 1),
+		),
+	)
+
+	tree, err := extensive.NewTree(root, 2)
+	if err != nil {
+		panic(err)
+	}
+
+	equilibria, err := tree.SubgamePerfectEquilibria(0)
+	if err != nil {
+		panic(err)
+	}
+
+	println("Subgame-perfect equilibria:")
+	for _, e := range equilibria {
+		println(e.String())
+	}
+
+	nf, err := tree.NormalForm()
+	if err != nil {
+		panic(err)
+	}
+
+	println("Normal form:")
+	println(nf.String())
+
+	g, err := nf.Bimatrix()
+	if err != nil {
+		panic(err)
+	}
+
+	println("Nash equilibria:")
+	for _, e := range g.PureEquilibria() {
+		println(e.String())
+	}
+}
+
+// ExtensiveZeroSumScript ExtensiveZeroSumScript
+func ExtensiveZeroSumScript() {
+	// matching pennies: the second player doesn't see the coin of the first one
+	root := extensive.Decision("first", 0, "", []string{"H", "T"},
+		extensive.Decision("second after H", 1, "second", []string{"h", "t"},
+			extensive.Terminal(1, -1),
+			extensive.Terminal(-1, 1),
+		),
+		extensive.Decision("second after T", 1, "second", []string{"h", "t"},
+			extensive.Terminal(-1, 1),
+			extensive.Terminal(1, -1),
+		),
+	)
+
+	tree, err := extensive.NewTree(root, 2)
+	if err != nil {
+		panic(err)
+	}
+
+	nf, err := tree.NormalForm()
+	if err != nil {
+		panic(err)
+	}
+
+	solution, err := game.SolveGame(nf.Matrix())
+	if err != nil {
+		panic(err)
+	}
+
+	println(nf.String())
+	println(solution.String())
+}
@@ -1,8 +1,10 @@
 package scripts
 
 import "gomo/game"
+import "gomo/graphical"
 import "gomo/matrix"
 import "gomo/lpt"
+import "os"
 import "strings"
 
 // GameBounds GameBounds
@@ -30,10 +32,28 @@ func GameSimplify() {
 		{5, 3, 4, 5},
 	}
 
-	simplified := game.Simplify(gameMatrix)
+	reduction := game.Reduce(gameMatrix, game.DominanceWeak, true)
 	println(gameMatrix.String())
 	println("to")
-	println(simplified.String())
+	println(reduction.String())
+}
+
+// GameSolveReduced GameSolveReduced
+func GameSolveReduced() {
+	m := matrix.Matrix{
+		{4, 0, 5},
+		{0, 4, 5},
+		{1, 1, 0},
+	}
+
+	solution, reduction, err := game.SolveGameReduced(m, game.DominanceStrict, true)
+	if err != nil {
+		panic(err)
+	}
+
+	println(reduction.String())
+	println()
+	println(solution.String())
 }
 
 // Game2x2 Game2x2
@@ -60,7 +80,10 @@ Z = 1x1 +1x2 +1x3 -> (min)`
 	l := lpt.ParseLPT(strings.Split(input, "\n")[1:])
 	ld := l.GenerateDualTask()
 	ldc := ld.CanonicalForm()
-	m := ldc.LimitationsAsMatrix().OriginalBaseVector()
+	m, err := ldc.LimitationsAsMatrix().OriginalBaseVector()
+	if err != nil {
+		panic(err)
+	}
 
 	ldcs, _ := ldc.SetMatrix(m).DoSimplex()
 
@@ -101,6 +124,110 @@ func GameSolve() {
 	// 	{2, 3, -7, 3},
 	// }
 
-	solution := game.SolveGame(m)
+	solution, err := game.SolveGame(m)
+	if err != nil {
+		panic(err)
+	}
+
+	println(solution.String())
+}
+
+// GameGraphical GameGraphical
+func GameGraphical() {
+	m := matrix.Matrix{
+		{2, 3, 11},
+		{7, 5, 2},
+	}
+
+	solution, err := graphical.SolveGame(m)
+	if err != nil {
+		panic(err)
+	}
+
+	println(solution.String())
+
+	if err := solution.Save("game.png"); err != nil {
+		panic(err)
+	}
+}
+
+// GameBrownRobinson GameBrownRobinson
+func GameBrownRobinson() {
+	m := matrix.Matrix{
+		{2, 3, 11},
+		{7, 5, 2},
+	}
+
+	steps := []game.BrownRobinsonStep{}
+	solution, err := game.SolveGameBrownRobinson(m, 0, 0.1, func(step game.BrownRobinsonStep) {
+		steps = append(steps, step)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	println(game.BrownRobinsonTable(steps))
+	println(solution.String())
+}
+
+// GameBimatrix GameBimatrix
+func GameBimatrix() {
+	g, err := game.NewBimatrix(
+		matrix.Matrix{
+			{3, 3},
+			{2, 5},
+			{0, 6},
+		},
+		matrix.Matrix{
+			{3, 2},
+			{2, 6},
+			{3, 1},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	println("Pure equilibria:")
+	for _, e := range g.PureEquilibria() {
+		println(e.String())
+		println()
+	}
+
+	println("Support enumeration:")
+	for _, e := range g.SupportEnumeration() {
+		println(e.String())
+		println()
+	}
+
+	e, err := g.LemkeHowson(0)
+	if err != nil {
+		panic(err)
+	}
+
+	println("Lemke-Howson:")
+	println(e.String())
+}
+
+// GameFromCSV GameFromCSV
+func GameFromCSV(path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	m, _, err := matrix.ReadCSV(file, false)
+	if err != nil {
+		panic(err)
+	}
+
+	solution, err := game.SolveGame(m)
+	if err != nil {
+		panic(err)
+	}
+
 	println(solution.String())
+	matrix.WriteVectorJSON(os.Stdout, solution.Probabilities1())
+	matrix.WriteVectorJSON(os.Stdout, solution.Probabilities2())
 }
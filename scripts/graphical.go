@@ -0,0 +1,32 @@
+package scripts
+
+import (
+	"gomo/graphical"
+	"gomo/lpt"
+	"strings"
+)
+
+// GraphicalScript GraphicalScript
+func GraphicalScript() {
+	input := `
+| 1x1 +2x2 <= 8
+| 3x1 +1x2 <= 9
+| -1x1 +1x2 <= 2
+1x1 >= 0, 1x2 >= 0
+Z = 1x1 +1x2 -> (max)`
+
+	l := lpt.ParseLPT(strings.Split(input, "\n")[1:])
+	println(l.String())
+	println()
+
+	solution, err := graphical.SolveLPT(l)
+	if err != nil {
+		panic(err)
+	}
+
+	println(solution.String())
+
+	if err := solution.Save("lpt.svg"); err != nil {
+		panic(err)
+	}
+}
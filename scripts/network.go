@@ -0,0 +1,59 @@
+package scripts
+
+import (
+	"gomo/network"
+)
+
+// NetworkScript NetworkScript
+func NetworkScript() {
+	g := network.Graph{}.
+		AddEdge(0, 1, 4, 2).
+		AddEdge(0, 2, 2, 2).
+		AddEdge(1, 2, 2, 1).
+		AddEdge(1, 3, 3, 3).
+		AddEdge(2, 3, 5, 1)
+
+	println(g.String())
+
+	maxFlow, err := g.MaxFlow(0, 3)
+	if err != nil {
+		panic(err)
+	}
+
+	println("Max flow:")
+	println(maxFlow.String())
+	println()
+
+	cut, err := g.MinCut(0, 3)
+	if err != nil {
+		panic(err)
+	}
+
+	println("Min cut:")
+	println(cut.String())
+	println()
+
+	minCostFlow, err := g.MinCostFlow(0, 3, maxFlow.Value)
+	if err != nil {
+		panic(err)
+	}
+
+	println("Min cost flow:")
+	println(minCostFlow.String())
+	println()
+
+	l := g.MinCostFlowLPT(0, 3, maxFlow.Value)
+	println("Equal LPT:")
+	println(l.String())
+
+	lc := l.CanonicalForm()
+	m, err := lc.LimitationsAsMatrix().OriginalBaseVector()
+	if err != nil {
+		panic(err)
+	}
+
+	lcs, _ := lc.SetMatrix(m).DoSimplex()
+	println()
+	println("Simplex result:")
+	println(lcs.LimitationsAsMatrix().String())
+}
@@ -14,5 +14,10 @@ func OriginalBaseVectorScript() {
 		{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1, 300},
 	}
 
-	println(m.OriginalBaseVector().String())
+	mb, err := m.OriginalBaseVector()
+	if err != nil {
+		panic(err)
+	}
+
+	println(mb.String())
 }
@@ -0,0 +1,50 @@
+package scripts
+
+import (
+	"fmt"
+	"gomo/network"
+	"gomo/project"
+)
+
+// ShortestPathScript ShortestPathScript
+func ShortestPathScript() {
+	g := network.Graph{}.
+		AddEdge(0, 1, 0, 7).
+		AddEdge(0, 2, 0, 9).
+		AddEdge(0, 5, 0, 14).
+		AddEdge(1, 2, 0, 10).
+		AddEdge(1, 3, 0, 15).
+		AddEdge(2, 3, 0, 11).
+		AddEdge(2, 5, 0, 2).
+		AddEdge(3, 4, 0, 6).
+		AddEdge(5, 4, 0, 9)
+
+	paths, err := g.Dijkstra(0)
+	if err != nil {
+		panic(err)
+	}
+
+	println(paths.String())
+}
+
+// ProjectScript ProjectScript
+func ProjectScript() {
+	tasks := []project.Task{
+		{Name: "A", Optimistic: 2, Duration: 3, Pessimistic: 6},
+		{Name: "B", Optimistic: 1, Duration: 2, Pessimistic: 3},
+		{Name: "C", Optimistic: 3, Duration: 4, Pessimistic: 8, Predecessors: []string{"A"}},
+		{Name: "D", Optimistic: 4, Duration: 6, Pessimistic: 9, Predecessors: []string{"A", "B"}},
+		{Name: "E", Optimistic: 1, Duration: 2, Pessimistic: 4, Predecessors: []string{"C"}},
+		{Name: "F", Optimistic: 1, Duration: 1, Pessimistic: 2, Predecessors: []string{"D", "E"}},
+	}
+
+	schedule, err := project.Analyze(tasks)
+	if err != nil {
+		panic(err)
+	}
+
+	println(schedule.String())
+
+	deadline := 12.0
+	println(fmt.Sprintf("P(T <= %.0f) = %.4f", deadline, schedule.Probability(deadline)))
+}
@@ -18,7 +18,10 @@ Z = 50x1 +100x2 +200x3 +160x4 +130x5 +170x6 -> (min)`
 	l := lpt.ParseLPT(strings.Split(input, "\n")[1:])
 	lc := l.CanonicalForm()
 
-	m := lc.LimitationsAsMatrix().OriginalBaseVector()
+	m, err := lc.LimitationsAsMatrix().OriginalBaseVector()
+	if err != nil {
+		panic(err)
+	}
 	println(m.String())
 
 	lcc := lc.SetMatrix(m)
@@ -0,0 +1,38 @@
+package scripts
+
+import (
+	"gomo/matrix"
+	"gomo/transport"
+)
+
+// TransportScript TransportScript
+func TransportScript() {
+	problem := transport.Problem{
+		Supply: matrix.Vector{850, 520},
+		Demand: matrix.Vector{410, 580, 350},
+		Costs: matrix.Matrix{
+			{50, 100, 200},
+			{160, 130, 170},
+		},
+	}
+
+	println(problem.String())
+
+	for _, method := range []transport.Method{transport.MethodNorthWest, transport.MethodLeastCost, transport.MethodVogel} {
+		balanced := problem.Balance()
+		plan := balanced.InitialPlan(method)
+
+		println("Initial plan by " + method.String() + " method:")
+		println(plan.Amounts.String())
+		println("cost:", matrix.HumaniazeValue(balanced.Cost(plan.Amounts)))
+		println()
+	}
+
+	solution, err := problem.Solve(transport.MethodVogel)
+	if err != nil {
+		panic(err)
+	}
+
+	println("Optimal plan:")
+	println(solution.String())
+}
@@ -0,0 +1,517 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"gomo/matrix"
+	"math"
+)
+
+var (
+	// ErrDimensionMismatch is returned when costs don't fit supply and demand
+	ErrDimensionMismatch = errors.New("transport: costs size doesn't match supply and demand")
+	// ErrNegativeAmount is returned when supply or demand has negative value
+	ErrNegativeAmount = errors.New("transport: supply and demand must be non-negative")
+	// ErrNotConverged is returned when the potentials method exceeds the iterations limit
+	ErrNotConverged = errors.New("transport: potentials method didn't converge")
+)
+
+// Method shows the way an initial plan is built
+type Method int
+
+const (
+	// MethodNorthWest is North-West corner method
+	MethodNorthWest Method = iota
+	// MethodLeastCost is least (minimal) cost method
+	MethodLeastCost Method = iota
+	// MethodVogel is Vogel's approximation method
+	MethodVogel Method = iota
+)
+
+func (method Method) String() string {
+	switch method {
+	case MethodNorthWest:
+		return "North-West corner"
+	case MethodLeastCost:
+		return "least cost"
+	case MethodVogel:
+		return "Vogel's approximation"
+	}
+
+	return "Undefined"
+}
+
+// Problem is a transportation problem: suppliers are rows, consumers are columns
+type Problem struct {
+	Supply matrix.Vector
+	Demand matrix.Vector
+	Costs  matrix.Matrix
+}
+
+// Plan is a transportation plan with its basic cells
+type Plan struct {
+	Amounts matrix.Matrix
+	Basis   [][]bool
+}
+
+// Solution contains data about a transportation problem solution
+type Solution struct {
+	// Plan is the optimal plan of the original (not balanced) problem
+	Plan matrix.Matrix
+	Cost float64
+	// Balanced is the problem the plan was found for
+	Balanced Problem
+	// DummySupplier and DummyConsumer show which dummy line was added
+	DummySupplier bool
+	DummyConsumer bool
+	// U and V are the potentials of suppliers and consumers
+	U          matrix.Vector
+	V          matrix.Vector
+	Iterations int
+}
+
+// Validate checks that the problem is well-formed
+func (p Problem) Validate() error {
+	if len(p.Costs) != len(p.Supply) || len(p.Supply) == 0 || len(p.Demand) == 0 {
+		return ErrDimensionMismatch
+	}
+
+	for _, row := range p.Costs {
+		if len(row) != len(p.Demand) {
+			return ErrDimensionMismatch
+		}
+	}
+
+	for _, v := range append(p.Supply.Clone(), p.Demand...) {
+		if v < 0 {
+			return ErrNegativeAmount
+		}
+	}
+
+	return nil
+}
+
+// IsBalanced checks if total supply equals total demand
+func (p Problem) IsBalanced() bool {
+	return math.Abs(p.Supply.Sum()-p.Demand.Sum()) < matrix.Epsilon
+}
+
+// Balance adds a dummy supplier or consumer with zero costs to make the problem balanced
+func (p Problem) Balance() Problem {
+	supplySum := p.Supply.Sum()
+	demandSum := p.Demand.Sum()
+
+	if p.IsBalanced() {
+		return Problem{p.Supply.Clone(), p.Demand.Clone(), p.Costs.Clone()}
+	}
+
+	if supplySum > demandSum {
+		costs := matrix.ShellM(len(p.Demand)+1, len(p.Supply)).FillWith(p.Costs)
+
+		return Problem{
+			Supply: p.Supply.Clone(),
+			Demand: append(p.Demand.Clone(), supplySum-demandSum),
+			Costs:  costs,
+		}
+	}
+
+	costs := append(p.Costs.Clone(), matrix.ShellV(len(p.Demand)))
+
+	return Problem{
+		Supply: append(p.Supply.Clone(), demandSum-supplySum),
+		Demand: p.Demand.Clone(),
+		Costs:  costs,
+	}
+}
+
+// Cost calculates total cost of the plan
+func (p Problem) Cost(amounts matrix.Matrix) float64 {
+	cost := 0.0
+	for y, row := range amounts {
+		cost += row.MultiplyElementByElement(p.Costs[y]).Sum()
+	}
+
+	return cost
+}
+
+// InitialPlan builds an initial basic plan of the balanced problem using provided method
+func (p Problem) InitialPlan(method Method) Plan {
+	h, w := len(p.Supply), len(p.Demand)
+
+	plan := Plan{
+		Amounts: matrix.ShellM(w, h),
+		Basis:   shellBasis(w, h),
+	}
+
+	supply := p.Supply.Clone()
+	demand := p.Demand.Clone()
+	rowsDone := make([]bool, h)
+	columnsDone := make([]bool, w)
+	rowsLeft, columnsLeft := h, w
+
+	for rowsLeft > 0 && columnsLeft > 0 {
+		var y, x int
+		switch method {
+		case MethodLeastCost:
+			y, x = p.leastCostCell(rowsDone, columnsDone)
+		case MethodVogel:
+			y, x = p.vogelCell(rowsDone, columnsDone)
+		default:
+			y, x = northWestCell(rowsDone, columnsDone)
+		}
+
+		amount := math.Min(supply[y], demand[x])
+		supply[y] -= amount
+		demand[x] -= amount
+		plan.Amounts[y][x] = amount
+		plan.Basis[y][x] = true
+
+		// cross out only one line at a time so the plan has exactly h+w-1 basic cells
+		rowIsEmpty := supply[y] <= matrix.Epsilon
+		columnIsEmpty := demand[x] <= matrix.Epsilon
+		switch {
+		case rowsLeft == 1 && columnsLeft == 1:
+			rowsDone[y] = true
+			columnsDone[x] = true
+			rowsLeft--
+			columnsLeft--
+		case rowIsEmpty && rowsLeft > 1:
+			rowsDone[y] = true
+			rowsLeft--
+		case columnIsEmpty && columnsLeft > 1:
+			columnsDone[x] = true
+			columnsLeft--
+		case rowIsEmpty:
+			rowsDone[y] = true
+			rowsLeft--
+		default:
+			columnsDone[x] = true
+			columnsLeft--
+		}
+	}
+
+	return plan
+}
+
+func shellBasis(width, height int) [][]bool {
+	basis := make([][]bool, height)
+	for y := range basis {
+		basis[y] = make([]bool, width)
+	}
+
+	return basis
+}
+
+func northWestCell(rowsDone, columnsDone []bool) (int, int) {
+	y, x := 0, 0
+	for rowsDone[y] {
+		y++
+	}
+	for columnsDone[x] {
+		x++
+	}
+
+	return y, x
+}
+
+func (p Problem) leastCostCell(rowsDone, columnsDone []bool) (int, int) {
+	minY, minX := -1, -1
+	minCost := math.MaxFloat64
+	for y, row := range p.Costs {
+		if rowsDone[y] {
+			continue
+		}
+
+		for x, cost := range row {
+			if !columnsDone[x] && cost < minCost {
+				minY, minX = y, x
+				minCost = cost
+			}
+		}
+	}
+
+	return minY, minX
+}
+
+// penalty returns difference between two smallest values of the line
+func penalty(line matrix.Vector, done []bool) float64 {
+	min1, min2 := math.MaxFloat64, math.MaxFloat64
+	for i, value := range line {
+		if done[i] {
+			continue
+		}
+
+		if value < min1 {
+			min1, min2 = value, min1
+		} else if value < min2 {
+			min2 = value
+		}
+	}
+
+	if min2 == math.MaxFloat64 {
+		return min1
+	}
+
+	return min2 - min1
+}
+
+func (p Problem) vogelCell(rowsDone, columnsDone []bool) (int, int) {
+	maxPenalty := -1.0
+	lineIndex := -1
+	lineIsRow := true
+
+	for y, row := range p.Costs {
+		if rowsDone[y] {
+			continue
+		}
+
+		if pen := penalty(row, columnsDone); pen > maxPenalty {
+			maxPenalty = pen
+			lineIndex = y
+			lineIsRow = true
+		}
+	}
+
+	columns := p.Costs.Transpose()
+	for x, column := range columns {
+		if columnsDone[x] {
+			continue
+		}
+
+		if pen := penalty(column, rowsDone); pen > maxPenalty {
+			maxPenalty = pen
+			lineIndex = x
+			lineIsRow = false
+		}
+	}
+
+	if lineIsRow {
+		x := minIndex(p.Costs[lineIndex], columnsDone)
+		return lineIndex, x
+	}
+
+	y := minIndex(columns[lineIndex], rowsDone)
+	return y, lineIndex
+}
+
+func minIndex(line matrix.Vector, done []bool) int {
+	index := -1
+	min := math.MaxFloat64
+	for i, value := range line {
+		if !done[i] && value < min {
+			index = i
+			min = value
+		}
+	}
+
+	return index
+}
+
+// Potentials calculates potentials u and v so that u_i + v_j = c_ij for every basic cell (u_1 = 0)
+func (p Problem) Potentials(plan Plan) (matrix.Vector, matrix.Vector) {
+	h, w := len(p.Supply), len(p.Demand)
+	u := matrix.ShellV(h)
+	v := matrix.ShellV(w)
+	uKnown := make([]bool, h)
+	vKnown := make([]bool, w)
+
+	uKnown[0] = true
+	for changed := true; changed; {
+		changed = false
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if !plan.Basis[y][x] {
+					continue
+				}
+
+				if uKnown[y] && !vKnown[x] {
+					v[x] = p.Costs[y][x] - u[y]
+					vKnown[x] = true
+					changed = true
+				} else if vKnown[x] && !uKnown[y] {
+					u[y] = p.Costs[y][x] - v[x]
+					uKnown[y] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	return u, v
+}
+
+// cycle finds a closed path that starts at the cell (y, x) and goes through basic cells only.
+// Returns cells of the path as [y, x] pairs, the first one is (y, x) itself
+func (plan Plan) cycle(y, x int) [][2]int {
+	h, w := len(plan.Basis), len(plan.Basis[0])
+
+	// nodes 0..h-1 are rows and h..h+w-1 are columns, basic cells are edges
+	previous := make([]int, h+w)
+	for i := range previous {
+		previous[i] = -1
+	}
+
+	start := h + x
+	previous[start] = start
+	queue := []int{start}
+	for len(queue) > 0 && previous[y] == -1 {
+		node := queue[0]
+		queue = queue[1:]
+
+		if node >= h {
+			column := node - h
+			for row := 0; row < h; row++ {
+				if plan.Basis[row][column] && previous[row] == -1 {
+					previous[row] = node
+					queue = append(queue, row)
+				}
+			}
+		} else {
+			for column := 0; column < w; column++ {
+				if plan.Basis[node][column] && previous[h+column] == -1 {
+					previous[h+column] = node
+					queue = append(queue, h+column)
+				}
+			}
+		}
+	}
+
+	if previous[y] == -1 {
+		return nil
+	}
+
+	path := [][2]int{{y, x}}
+	for node := y; node != start; node = previous[node] {
+		next := previous[node]
+		if node < h {
+			path = append(path, [2]int{node, next - h})
+		} else {
+			path = append(path, [2]int{next, node - h})
+		}
+	}
+
+	return path
+}
+
+// Improve performs one iteration of the potentials method. Returns false if the plan is already optimal.
+// Bland's rule is used so degenerate plans don't cycle: the first improving cell in row-major order enters,
+// the first one in the same order leaves among the cells limiting the amount
+func (p Problem) Improve(plan Plan) (Plan, bool) {
+	u, v := p.Potentials(plan)
+
+	enterY, enterX := p.enteringCell(plan, u, v)
+	if enterY == -1 {
+		return plan, false
+	}
+
+	path := plan.cycle(enterY, enterX)
+
+	// odd cells of the path are the ones the amount is taken from
+	theta := 0.0
+	leaving := -1
+	for i := 1; i < len(path); i += 2 {
+		cell := path[i]
+		amount := plan.Amounts[cell[0]][cell[1]]
+		if leaving == -1 || amount < theta-matrix.Epsilon ||
+			(amount <= theta+matrix.Epsilon && cellPrecedes(cell, path[leaving])) {
+			theta = amount
+			leaving = i
+		}
+	}
+
+	amounts := plan.Amounts.Clone()
+	basis := shellBasis(len(p.Demand), len(p.Supply))
+	for y, row := range plan.Basis {
+		copy(basis[y], row)
+	}
+
+	for i, cell := range path {
+		if i%2 == 0 {
+			amounts[cell[0]][cell[1]] += theta
+		} else {
+			amounts[cell[0]][cell[1]] -= theta
+		}
+	}
+
+	basis[enterY][enterX] = true
+	basis[path[leaving][0]][path[leaving][1]] = false
+	amounts[path[leaving][0]][path[leaving][1]] = 0
+
+	return Plan{amounts, basis}, true
+}
+
+// enteringCell returns the first non-basic cell with negative reduced cost c_ij - u_i - v_j, (-1, -1) if none
+func (p Problem) enteringCell(plan Plan, u, v matrix.Vector) (int, int) {
+	for y, row := range p.Costs {
+		for x, cost := range row {
+			if !plan.Basis[y][x] && cost-u[y]-v[x] < -matrix.Epsilon {
+				return y, x
+			}
+		}
+	}
+
+	return -1, -1
+}
+
+// cellPrecedes checks if the cell a goes before b in row-major order
+func cellPrecedes(a, b [2]int) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+// Solve balances the problem, builds an initial plan with provided method and optimises it with the potentials method
+func (p Problem) Solve(method Method) (Solution, error) {
+	if err := p.Validate(); err != nil {
+		return Solution{}, err
+	}
+
+	balanced := p.Balance()
+	plan := balanced.InitialPlan(method)
+
+	// Bland's rule can't cycle, the limit guards against the rounding errors only
+	limit := 100 * len(balanced.Supply) * len(balanced.Demand)
+
+	iterations := 0
+	for improved := true; improved; {
+		plan, improved = balanced.Improve(plan)
+		if improved {
+			iterations++
+		}
+
+		if iterations > limit {
+			return Solution{}, ErrNotConverged
+		}
+	}
+
+	u, v := balanced.Potentials(plan)
+	// drop the dummy line
+	amounts := matrix.ShellM(len(p.Demand), len(p.Supply))
+	for y, row := range amounts {
+		copy(row, plan.Amounts[y])
+	}
+
+	return Solution{
+		Plan:          amounts,
+		Cost:          p.Cost(amounts),
+		Balanced:      balanced,
+		DummySupplier: len(balanced.Supply) > len(p.Supply),
+		DummyConsumer: len(balanced.Demand) > len(p.Demand),
+		U:             u,
+		V:             v,
+		Iterations:    iterations,
+	}, nil
+}
+
+// String stringifies the problem as a cost table with supply at the right and demand at the bottom
+func (p Problem) String() string {
+	m := matrix.ShellM(len(p.Demand)+1, len(p.Supply)+1).FillWith(p.Costs)
+	for y, value := range p.Supply {
+		m[y][len(p.Demand)] = value
+	}
+	copy(m[len(p.Supply)], p.Demand)
+
+	return m.String()
+}
+
+func (s Solution) String() string {
+	return fmt.Sprintf("plan:\n%scost:\t%f\nu:\t[%s]\nv:\t[%s]\niterations:\t%d", s.Plan, s.Cost, s.U, s.V, s.Iterations)
+}
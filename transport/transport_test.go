@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"gomo/matrix"
+	"math"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		problem  Problem
+		wantCost float64
+	}{
+		{"balanced", Problem{
+			Supply: matrix.Vector{20, 30, 25},
+			Demand: matrix.Vector{10, 10, 35, 20},
+			Costs: matrix.Matrix{
+				{8, 6, 10, 9},
+				{9, 12, 13, 7},
+				{14, 9, 16, 5},
+			},
+		}, 675},
+		{"supply excess", Problem{
+			Supply: matrix.Vector{850, 520},
+			Demand: matrix.Vector{410, 580, 350},
+			Costs: matrix.Matrix{
+				{50, 100, 200},
+				{160, 130, 170},
+			},
+		}, 50*410 + 100*440 + 130*140 + 170*350},
+		{"demand excess", Problem{
+			Supply: matrix.Vector{30, 40},
+			Demand: matrix.Vector{20, 30, 40},
+			Costs: matrix.Matrix{
+				{4, 8, 8},
+				{16, 24, 16},
+			},
+		}, 4*20 + 8*10 + 16*40},
+		{"degenerate", Problem{
+			Supply: matrix.Vector{10, 20, 30},
+			Demand: matrix.Vector{10, 20, 30},
+			Costs: matrix.Matrix{
+				{1, 2, 3},
+				{2, 1, 3},
+				{3, 3, 1},
+			},
+		}, 60},
+		// every basic plan of the assignment problem has zero amounts in the basis
+		{"assignment", Problem{
+			Supply: matrix.Vector{1, 1, 1, 1},
+			Demand: matrix.Vector{1, 1, 1, 1},
+			Costs: matrix.Matrix{
+				{9, 2, 7, 8},
+				{6, 4, 3, 7},
+				{5, 8, 1, 8},
+				{7, 6, 9, 4},
+			},
+		}, 13},
+	}
+	for _, tt := range tests {
+		for _, method := range []Method{MethodNorthWest, MethodLeastCost, MethodVogel} {
+			t.Run(tt.name+" "+method.String(), func(t *testing.T) {
+				got, err := tt.problem.Solve(method)
+				if err != nil {
+					t.Fatalf("Solve() error = %v", err)
+				}
+				if math.Abs(got.Cost-tt.wantCost) > 1e-6 {
+					t.Errorf("Solve() cost = %v, want %v\n%s", got.Cost, tt.wantCost, got)
+				}
+
+				for y, row := range got.Plan {
+					if row.Sum() > tt.problem.Supply[y]+1e-6 {
+						t.Errorf("Solve() supplier %d ships %v of %v", y, row.Sum(), tt.problem.Supply[y])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestInitialPlan(t *testing.T) {
+	p := Problem{
+		Supply: matrix.Vector{20, 30, 25},
+		Demand: matrix.Vector{10, 10, 35, 20},
+		Costs: matrix.Matrix{
+			{8, 6, 10, 9},
+			{9, 12, 13, 7},
+			{14, 9, 16, 5},
+		},
+	}
+
+	for _, method := range []Method{MethodNorthWest, MethodLeastCost, MethodVogel} {
+		t.Run(method.String(), func(t *testing.T) {
+			plan := p.InitialPlan(method)
+
+			basicCount := 0
+			for _, row := range plan.Basis {
+				for _, isBasic := range row {
+					if isBasic {
+						basicCount++
+					}
+				}
+			}
+			if basicCount != len(p.Supply)+len(p.Demand)-1 {
+				t.Errorf("InitialPlan() has %d basic cells", basicCount)
+			}
+
+			for x, column := range plan.Amounts.Transpose() {
+				if math.Abs(column.Sum()-p.Demand[x]) > 1e-9 {
+					t.Errorf("InitialPlan() consumer %d gets %v, want %v", x, column.Sum(), p.Demand[x])
+				}
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	p := Problem{
+		Supply: matrix.Vector{1, 2},
+		Demand: matrix.Vector{3},
+		Costs:  matrix.Matrix{{1, 2}},
+	}
+
+	if err := p.Validate(); err != ErrDimensionMismatch {
+		t.Errorf("Validate() = %v, want %v", err, ErrDimensionMismatch)
+	}
+}